@@ -1,19 +1,32 @@
+import math
+
 from PyQt6.QtWidgets import (
     QMainWindow, QWidget, QVBoxLayout, 
     QComboBox, QLabel, QStackedWidget,
-    QPushButton, QTextEdit
+    QPushButton, QTextEdit, QHBoxLayout, QCheckBox,
+    QDoubleSpinBox, QTabWidget
 )
 from PyQt6.QtCore import Qt
 from .prng_selector import PRNGSelector, ParameterForm
+from .plots import ECDFPlot, ScatterPlot, Scatter3DPlot
 
-# Import PRNG implementations
-from prng.mid_square import generate_sequence as von_neumann_generate
-from prng.fibonacci import generate_sequence as fibonacci_generate
-from prng.congruential_mixed import generate_sequence as mixed_generate
-from prng.congruential_additive import generate_sequence as additive_generate
-from prng.congruential_multiplicative import generate_sequence as multiplicative_generate
-from prng.normaliser import normaliser
 from prng.chi_square import chi_square_test
+from prng.kolmogorov_smirnov import ks_test, EXACT_LIMIT
+from prng.gap import gap_test
+from prng.poker import HANDS, poker_counts, poker_test
+from prng.serial import serial_test
+from prng.runs import (
+    runs_up_down_test, runs_above_below_test,
+    runs_up_down_length_test, runs_above_below_length_test
+)
+from prng.degeneration import POLICIES, generate_monitored
+from prng.middle_square_weyl import compare_with_mid_square
+
+# Longest sequence printed in full, larger ones are truncated for display
+DISPLAY_LIMIT = 1000
+
+# Rows of the side-by-side comparison with Von Neumann's method
+COMPARISON_ROWS = 50
 
 class MainWindow(QMainWindow):
     def __init__(self):
@@ -35,6 +48,52 @@ class MainWindow(QMainWindow):
         # Create stacked widget for parameter forms
         self.parameter_stack = QStackedWidget()
         
+        # Create parameter advice display, refreshed as parameters change
+        self.advice_label = QLabel()
+        self.advice_label.setWordWrap(True)
+        
+        # Create degeneration policy selector for the digit methods
+        self.degeneration_box = QWidget()
+        degeneration_layout = QHBoxLayout(self.degeneration_box)
+        degeneration_layout.setContentsMargins(0, 0, 0, 0)
+        self.degeneration_policy = QComboBox()
+        self.degeneration_policy.addItems(POLICIES)
+        degeneration_layout.addWidget(QLabel("On degeneration:"))
+        degeneration_layout.addWidget(self.degeneration_policy)
+        
+        # Create side-by-side comparison switch for the middle-square Weyl sequence
+        self.comparison_box = QCheckBox("Compare side by side with Von Neumann from the same seed")
+        
+        # Create period analysis switch, off by default as Brent's algorithm
+        # may walk up to two million states for long or unknown cycles
+        self.period_box = QCheckBox("Analyse the period (can be slow for long cycles)")
+        
+        # Create the options of the statistical tests
+        self.test_options_box = QWidget()
+        test_options_layout = QHBoxLayout(self.test_options_box)
+        test_options_layout.setContentsMargins(0, 0, 0, 0)
+        self.alpha = QDoubleSpinBox()
+        self.alpha.setDecimals(3)
+        self.alpha.setRange(0.001, 0.5)
+        self.alpha.setSingleStep(0.01)
+        self.alpha.setValue(0.05)
+        self.poker_digits = QComboBox()
+        self.poker_digits.addItems([str(digits) for digits in sorted(HANDS, reverse=True)])
+        test_options_layout.addWidget(QLabel("Significance level (alpha):"))
+        test_options_layout.addWidget(self.alpha)
+        self.gap_low = QDoubleSpinBox()
+        self.gap_high = QDoubleSpinBox()
+        for spin_box, value in ((self.gap_low, 0.0), (self.gap_high, 0.5)):
+            spin_box.setDecimals(2)
+            spin_box.setRange(0.0, 1.0)
+            spin_box.setSingleStep(0.05)
+            spin_box.setValue(value)
+        test_options_layout.addWidget(QLabel("Poker test digits:"))
+        test_options_layout.addWidget(self.poker_digits)
+        test_options_layout.addWidget(QLabel("Gap test interval:"))
+        test_options_layout.addWidget(self.gap_low)
+        test_options_layout.addWidget(self.gap_high)
+        
         # Create generate button
         self.generate_button = QPushButton("Generate")
         self.generate_button.clicked.connect(self.on_generate)
@@ -50,15 +109,32 @@ class MainWindow(QMainWindow):
             "- a should be odd, not divisible by 3 nor 5\n"
             "- c should be 8 * a +- 3\n"
             "- m should be a large number, and greater than all other parameters\n"
-            "- For example: m = 2^x - 1 (Mersenne prime)\n"
+            "- For example: m = 2^x - 1 (Mersenne prime)\n\n"
+            "For Mixed Congruential, full period m is guaranteed by the\n"
+            "Hull-Dobell conditions checked under the parameters\n"
         )
         
+        # Create plots, shown in tabs next to the text results
+        self.ecdf_plot = ECDFPlot()
+        self.scatter_plot = ScatterPlot()
+        self.scatter_3d_plot = Scatter3DPlot()
+        self.results_tabs = QTabWidget()
+        self.results_tabs.addTab(self.results_display, "Results")
+        self.results_tabs.addTab(self.ecdf_plot, "Empirical CDF")
+        self.results_tabs.addTab(self.scatter_plot, "2-D lattice")
+        self.results_tabs.addTab(self.scatter_3d_plot, "3-D lattice")
+        
         # Add widgets to layout
         layout.addWidget(prng_label)
         layout.addWidget(self.prng_selector)
         layout.addWidget(self.parameter_stack)
+        layout.addWidget(self.advice_label)
+        layout.addWidget(self.degeneration_box)
+        layout.addWidget(self.comparison_box)
+        layout.addWidget(self.period_box)
+        layout.addWidget(self.test_options_box)
         layout.addWidget(self.generate_button)
-        layout.addWidget(self.results_display)
+        layout.addWidget(self.results_tabs)
         
         # Initialize with first method
         self.on_method_changed(self.prng_selector.currentText())
@@ -66,8 +142,8 @@ class MainWindow(QMainWindow):
     def on_method_changed(self, method_name):
         """Handle PRNG method selection change."""
         # Create parameter form for selected method
-        params = PRNGSelector.METHODS[method_name]["params"]
-        form = ParameterForm(params)
+        method = PRNGSelector.METHODS[method_name]
+        form = (method["form"] or ParameterForm)(method["params"], method["presets"])
         
         # Remove all widgets from stack
         while self.parameter_stack.count():
@@ -75,6 +151,26 @@ class MainWindow(QMainWindow):
         
         # Add new form to stack
         self.parameter_stack.addWidget(form)
+        self.degeneration_box.setVisible(method["degeneration"])
+        self.comparison_box.setVisible(method["comparison"])
+        
+        form.valueChanged.connect(self.on_parameters_changed)
+        self.on_parameters_changed()
+    
+    def on_parameters_changed(self):
+        """Refresh the advice for the current parameters."""
+        advisor = PRNGSelector.METHODS[self.prng_selector.currentText()]["advisor"]
+        form = self.parameter_stack.currentWidget()
+        if advisor is None or form is None:
+            self.advice_label.setText("")
+            self.advice_label.setVisible(False)
+            return
+        try:
+            advice = advisor(form.get_values())
+        except ValueError as e:
+            advice = str(e)
+        self.advice_label.setText(advice)
+        self.advice_label.setVisible(True)
     
     def on_generate(self):
         """Handle generate button click."""
@@ -83,72 +179,230 @@ class MainWindow(QMainWindow):
         if not current_form:
             return
         
-        # Get parameter values
-        params = current_form.get_values()
         method = self.prng_selector.currentText()
         
         try:
-            sequence = None  # Initialize sequence variable
-            # Call appropriate PRNG method
-            if method == "Von Neumann":
-                sequence = von_neumann_generate(
-                    n=params['n'],
-                    d=params['d'],
-                    x1=params['x1']
-                )
-                # Normalize using 10^d
-                normalized = normaliser(sequence, 10 ** params['d'])
-            elif method == "Fibonacci":
-                sequence = fibonacci_generate(
-                    n=params['n'],
-                    x0=params['x0'],
-                    x1=params['x1'],
-                    m=params['m']
-                )
-                normalized = normaliser(sequence, params['m'])
-            elif method == "Mixed Congruential":
-                sequence = mixed_generate(
-                    n=params['n'],
-                    x0=params['x0'],
-                    a=params['a'],
-                    c=params['c'],
-                    m=params['m']
-                )
-                normalized = normaliser(sequence, params['m'])
-            elif method == "Additive Congruential":
-                sequence = additive_generate(
-                    n=params['n'],
-                    x0=params['x0'],
-                    c=params['c'],
-                    m=params['m']
-                )
-                normalized = normaliser(sequence, params['m'])
-            elif method == "Multiplicative Congruential":
-                sequence = multiplicative_generate(
-                    n=params['n'],
-                    x0=params['x0'],
-                    a=params['a'],
-                    m=params['m']
-                )
-                normalized = normaliser(sequence, params['m'])
+            # Get parameter values
+            params = current_form.get_values()
+            
+            # Build the selected generator from its parameters and draw n values
+            generator_class = PRNGSelector.METHODS[method]["generator"]
+            generator = generator_class(
+                **{name: value for name, value in params.items() if name != 'n'}
+            )
             
-            if sequence is None:
-                raise ValueError("No sequence was generated")
+            # Detect where the sequence starts repeating (before any reseeding)
+            period_report = ""
+            if self.period_box.isChecked():
+                period_report = "\n\n" + self.format_period(generator, params['n'])
+            
+            degeneration_report = ""
+            if PRNGSelector.METHODS[method]["degeneration"]:
+                sequence, events = generate_monitored(
+                    generator, params['n'], self.degeneration_policy.currentText()
+                )
+                degeneration_report = self.format_degeneration(events, params['n'], len(sequence))
+                if not sequence:
+                    raise ValueError(f"No values were generated\n\n{degeneration_report}")
+                degeneration_report += "\n\n"
+            else:
+                sequence = generator.generate(params['n'])
+            normalized = [generator.normalize(value) for value in sequence]
             
-            # Perform Chi-Square test
+            # Perform Chi-Square and Kolmogorov-Smirnov tests
+            alpha = self.alpha.value()
             chi_square, p_value, df = chi_square_test(normalized)
+            ks_report = self.format_ks(normalized, alpha)
+            runs_report = self.format_runs(normalized, alpha)
+            poker_report = self.format_poker(normalized, int(self.poker_digits.currentText()), alpha)
+            gap_report = self.format_gap(normalized, self.gap_low.value(), self.gap_high.value(), alpha)
+            serial_report = self.format_serial(normalized, alpha)
+            self.ecdf_plot.plot(normalized)
+            self.scatter_plot.plot(normalized)
+            self.scatter_3d_plot.plot(normalized)
+            
+            comparison_report = ""
+            if PRNGSelector.METHODS[method]["comparison"] and self.comparison_box.isChecked():
+                comparison_report = "\n\n" + self.format_comparison(params['n'], params['x0'])
             
             # Display results
             self.results_display.setText(
                 f"Parameters: {params}\n\n"
-                f"Generated sequence:\n{sequence}\n\n"
-                f"Normalized sequence:\n{normalized}\n\n"
+                f"{degeneration_report}"
+                f"Generated sequence:\n{self.format_values(sequence)}\n\n"
+                f"Normalized sequence:\n{self.format_values(normalized)}\n\n"
                 f"Chi-Square Test Results:\n"
                 f"Chi-Square value: {chi_square:.4f}\n"
                 f"Degrees of freedom: {df}\n"
                 f"p-value: {p_value:.4f}\n"
-                f"Interpretation: {'Pass' if p_value > 0.05 else 'Fail'} "
-                f"({'Random' if p_value > 0.05 else 'Not random'})"
+                f"Interpretation: {'Pass' if p_value > alpha else 'Fail'} "
+                f"({'Random' if p_value > alpha else 'Not random'})\n\n"
+                f"{ks_report}\n\n"
+                f"{runs_report}\n\n"
+                f"{poker_report}\n\n"
+                f"{gap_report}\n\n"
+                f"{serial_report}"
+                f"{period_report}"
+                f"{comparison_report}"
             )
         except Exception as e:
-            self.results_display.setText(f"Error: {str(e)}") 
\ No newline at end of file
+            self.results_display.setText(f"Error: {str(e)}") 
+    
+    def format_ks(self, normalized, alpha):
+        """Run the Kolmogorov-Smirnov test and describe its result."""
+        d_plus, d_minus, d, p_value, critical_value = ks_test(normalized, alpha)
+        method = "exact" if len(normalized) <= EXACT_LIMIT else "asymptotic"
+        passed = d <= critical_value
+        return (
+            f"Kolmogorov-Smirnov Test Results:\n"
+            f"D+: {d_plus:.4f}\n"
+            f"D-: {d_minus:.4f}\n"
+            f"D: {d:.4f}\n"
+            f"Critical value (alpha = {alpha}): {critical_value:.4f}\n"
+            f"p-value ({method}): {p_value:.4f}\n"
+            f"Interpretation: {'Pass' if passed else 'Fail'} "
+            f"({'Uniform' if passed else 'Not uniform'})"
+        )
+    
+    def format_runs(self, normalized, alpha):
+        """Run the runs tests (count and length distribution) and describe their results."""
+        lines = ["Runs Test Results:"]
+        for name, count_test, length_test in (
+            ("Runs up and down", runs_up_down_test, runs_up_down_length_test),
+            ("Runs above and below the mean", runs_above_below_test, runs_above_below_length_test)
+        ):
+            try:
+                runs, expected, variance, z, p_value = count_test(normalized)
+                lines.append(
+                    f"{name}: {runs} runs (expected {expected:.2f}, variance {variance:.2f}), "
+                    f"z = {z:.4f}, p-value = {p_value:.4f}: {'Pass' if p_value > alpha else 'Fail'}"
+                )
+                chi_square, p_value, df, table = length_test(normalized)
+                lines.append(
+                    f"{name}, run lengths: Chi-Square = {chi_square:.4f}, df = {df}, "
+                    f"p-value = {p_value:.4f}: {'Pass' if p_value > alpha else 'Fail'}"
+                )
+                lines.append(self.format_table(("Length", "Observed", "Expected"), table))
+            except ValueError as e:
+                lines.append(f"{name}: not available: {str(e)}")
+        return "\n".join(lines)
+    
+    def format_poker(self, normalized, digits, alpha):
+        """Run the poker test and show the observed and expected hands."""
+        lines = [f"Poker Test Results ({digits} digits):"]
+        labels, observed, expected = poker_counts(normalized, digits)
+        lines.append(self.format_table(("Hand", "Observed", "Expected"), zip(labels, observed, expected)))
+        try:
+            chi_square, p_value, df, table = poker_test(normalized, digits)
+        except ValueError as e:
+            lines.append(f"Chi-Square not available: {str(e)}")
+            return "\n".join(lines)
+        merged = [label for label, _, _ in table if label not in labels]
+        if merged:
+            lines.append(f"Merged for the Chi-Square: {', '.join(merged)}")
+        lines.append(
+            f"Chi-Square = {chi_square:.4f}, df = {df}, p-value = {p_value:.4f}: "
+            f"{'Pass' if p_value > alpha else 'Fail'}"
+        )
+        return "\n".join(lines)
+    
+    def format_gap(self, normalized, low, high, alpha):
+        """Run the gap test over [low, high) and show the observed and expected gaps."""
+        title = f"Gap Test Results (interval [{low}, {high})):"
+        try:
+            chi_square, p_value, df, table = gap_test(normalized, low, high)
+        except ValueError as e:
+            return f"{title}\nNot available: {str(e)}"
+        return "\n".join([
+            title,
+            self.format_table(("Gap length", "Observed", "Expected"), table),
+            f"Chi-Square = {chi_square:.4f}, df = {df}, p-value = {p_value:.4f}: "
+            f"{'Pass' if p_value > alpha else 'Fail'}"
+        ])
+    
+    def format_serial(self, normalized, alpha):
+        """Run the serial test on pairs and triples, non-overlapping and overlapping."""
+        lines = ["Serial Test Results:"]
+        for d in (2, 3):
+            for overlapping in (False, True):
+                name = f"{'Overlapping' if overlapping else 'Non-overlapping'} {d}-tuples"
+                try:
+                    chi_square, p_value, df, k = serial_test(normalized, d, overlapping=overlapping)
+                except ValueError as e:
+                    lines.append(f"{name}: not available: {str(e)}")
+                    continue
+                lines.append(
+                    f"{name} ({k}^{d} cells): Chi-Square = {chi_square:.4f}, df = {df}, "
+                    f"p-value = {p_value:.4f}: {'Pass' if p_value > alpha else 'Fail'}"
+                )
+        lines.append("Overlapping tuples use Good's statistic psi^2_d - psi^2_(d-1)")
+        return "\n".join(lines)
+    
+    def format_table(self, headers, rows):
+        """Print (label, observed, expected) rows as aligned columns."""
+        lines = [f"  {headers[0]:>24}  {headers[1]:>10}  {headers[2]:>12}"]
+        for label, observed, expected in rows:
+            lines.append(f"  {label:>24}  {observed:>10}  {expected:>12.2f}")
+        return "\n".join(lines)
+    
+    def format_values(self, values):
+        """Print the values, truncated to DISPLAY_LIMIT for long sequences."""
+        if len(values) <= DISPLAY_LIMIT:
+            return str(values)
+        shown = ", ".join(str(value) for value in values[:DISPLAY_LIMIT])
+        return f"[{shown}, ...] (first {DISPLAY_LIMIT} of {len(values)} values)"
+    
+    def format_period(self, generator, n):
+        """Describe the tail and cycle length of the generator."""
+        max_steps = 10 ** 6
+        try:
+            period = generator.period(max_steps)
+        except ValueError as e:
+            return f"Period Analysis:\nNot available: {str(e)}"
+        if period is None:
+            return f"Period Analysis:\nCycle length is greater than {max_steps}"
+        mu, lam = period
+        # Very long cycles are shown as a power of two
+        cycle = str(lam) if lam < 10 ** 30 else f"about 2^{math.log2(lam):.1f}"
+        report = (
+            f"Period Analysis:\n"
+            f"Tail length (mu): {mu}\n"
+            f"Cycle length (lambda): {cycle}"
+        )
+        if n > mu + lam:
+            report += (
+                f"\nWarning: the sequence repeats after {mu + lam} values, "
+                f"so the test results above are based on a repeated cycle"
+            )
+        return report
+    
+    def format_comparison(self, n, seed):
+        """Run Von Neumann's method and msws from the same seed and show them side by side."""
+        d = len(str(seed))
+        (square, square_events), (weyl, weyl_events) = compare_with_mid_square(n, d, seed)
+        lines = [
+            f"Side-by-side comparison from seed {seed} (Von Neumann with d = {d}):",
+            f"{'i':>5}  {'Von Neumann':>12}  {'Middle-Square Weyl':>18}"
+        ]
+        for index in range(min(n, COMPARISON_ROWS)):
+            lines.append(f"{index + 1:>5}  {square[index]:>12.4f}  {weyl[index]:>18.4f}")
+        if n > COMPARISON_ROWS:
+            lines.append(f"(first {COMPARISON_ROWS} of {n} values)")
+        lines.append("")
+        lines.append(f"Von Neumann - {self.format_degeneration(square_events, n, len(square))}")
+        lines.append(f"Middle-Square Weyl - {self.format_degeneration(weyl_events, n, len(weyl))}")
+        return "\n".join(lines)
+    
+    def format_degeneration(self, events, n, generated):
+        """Describe the degeneration events found while generating."""
+        if not events:
+            return "Degeneration Analysis:\nNo degeneration in the generated values"
+        lines = ["Degeneration Analysis:", "WARNING: the sequence degenerated"]
+        for event in events:
+            lines.append(
+                f"- value {event.index + 1}: {event.kind} ({event.value}, "
+                f"repeating every {event.cycle_length} values), {event.action}"
+            )
+        if generated < n:
+            lines.append(f"Only {generated} of the {n} requested values were generated")
+        return "\n".join(lines)
\ No newline at end of file
@@ -0,0 +1,85 @@
+from matplotlib.backends.backend_qtagg import FigureCanvasQTAgg
+from matplotlib.figure import Figure
+
+from prng.kolmogorov_smirnov import ecdf_points, largest_deviation
+
+# Most points drawn in a plot, longer sequences are thinned out evenly
+PLOT_POINTS = 5000
+
+def thin(values, limit=PLOT_POINTS):
+    """Return at most limit values, taken at even steps."""
+    step = max(1, len(values) // limit)
+    return values[::step]
+
+class PlotCanvas(FigureCanvasQTAgg):
+    """Base class for the matplotlib figures shown in the results tabs."""
+    
+    def __init__(self, projection=None):
+        self.figure = Figure(figsize=(5, 4))
+        super().__init__(self.figure)
+        self.projection = projection
+        self.axes = self.figure.add_subplot(projection=projection)
+    
+    def clear(self):
+        """Remove the current plot."""
+        self.axes.clear()
+        self.draw_idle()
+
+class ECDFPlot(PlotCanvas):
+    """Empirical CDF of the normalized values against the uniform CDF."""
+    
+    def plot(self, normalized):
+        self.axes.clear()
+        xs, ys = ecdf_points(normalized)
+        self.axes.step(thin(xs), thin(ys), where="post", label="Empirical CDF")
+        self.axes.plot([0, 1], [0, 1], linestyle="--", label="Uniform CDF")
+        
+        # Mark the largest distance, the KS statistic D
+        u, below, above = largest_deviation(normalized)
+        self.axes.plot([u, u], [below, above], color="red", linewidth=2, label="Largest deviation")
+        self.axes.plot([u, u], [min(below, u), max(above, u)], color="red", linestyle=":")
+        
+        self.axes.set_xlim(0, 1)
+        self.axes.set_ylim(0, 1)
+        self.axes.set_xlabel("u")
+        self.axes.set_ylabel("F(u)")
+        self.axes.set_title(f"Empirical CDF (n = {len(normalized)})")
+        self.axes.legend(loc="upper left")
+        self.draw_idle()
+
+class LatticePlot(PlotCanvas):
+    """Scatter plot of overlapping d-tuples (u_i, ..., u_{i+d-1}), showing the lattice structure."""
+    
+    # Dimension of the tuples, 2 or 3
+    DIMENSION = 2
+    
+    def __init__(self):
+        super().__init__("3d" if self.DIMENSION == 3 else None)
+    
+    def plot(self, normalized):
+        self.axes.clear()
+        d = self.DIMENSION
+        points = thin(list(zip(*(normalized[i:] for i in range(d)))))
+        if points:
+            self.axes.scatter(*zip(*points), s=1)
+        
+        labels = ("u_i", "u_{i+1}", "u_{i+2}")
+        self.axes.set_xlim(0, 1)
+        self.axes.set_ylim(0, 1)
+        self.axes.set_xlabel(f"${labels[0]}$")
+        self.axes.set_ylabel(f"${labels[1]}$")
+        if d == 3:
+            self.axes.set_zlim(0, 1)
+            self.axes.set_zlabel(f"${labels[2]}$")
+        self.axes.set_title(f"{d}-D lattice ({len(points)} points)")
+        self.draw_idle()
+
+class ScatterPlot(LatticePlot):
+    """Pairs (u_i, u_{i+1}); congruential generators fall on a few parallel lines."""
+    
+    DIMENSION = 2
+
+class Scatter3DPlot(LatticePlot):
+    """Triples (u_i, u_{i+1}, u_{i+2}), which can be rotated to find the planes (e.g. RANDU's 15)."""
+    
+    DIMENSION = 3
@@ -1,68 +1,386 @@
-from PyQt6.QtWidgets import QWidget, QVBoxLayout, QFormLayout, QSpinBox, QLabel
+from functools import cache
+
+from PyQt6.QtWidgets import (
+    QWidget, QVBoxLayout, QFormLayout, QSpinBox, QLabel,
+    QComboBox, QLineEdit, QStackedWidget
+)
+from PyQt6.QtCore import pyqtSignal, QRegularExpression
+from PyQt6.QtGui import QRegularExpressionValidator
+
+from prng.mid_square import MidSquareGenerator
+from prng.mid_product import MidProductGenerator
+from prng.fibonacci import FibonacciGenerator, LaggedFibonacciGenerator, LAG_PRESETS
+from prng.congruential_mixed import MixedCongruentialGenerator
+from prng.congruential_additive import AdditiveCongruentialGenerator
+from prng.congruential_multiplicative import MultiplicativeCongruentialGenerator
+from prng.congruential_quadratic import QuadraticCongruentialGenerator, advise as quadratic_advise
+from prng.congruential_inversive import InversiveCongruentialGenerator, advise as inversive_advise
+from prng.combined import WichmannHillGenerator, LEcuyerGenerator, MRG32k3aGenerator
+from prng.lfsr import (
+    LFSRGenerator, PRIMITIVE_POLYNOMIALS, polynomial_from_taps, format_polynomial,
+    advise as lfsr_advise
+)
+from prng.xorshift import Xorshift32Generator, Xorshift64Generator
+from prng.xoshiro import Xoshiro256StarStarGenerator
+from prng.splitmix import SplitMix64Generator
+from prng.pcg import PCG32Generator
+from prng.chacha import ChaCha20Generator
+from prng.philox import Philox4x32Generator
+from prng.middle_square_weyl import MiddleSquareWeylGenerator
+from prng.mersenne_twister import MersenneTwisterGenerator, advise as mersenne_twister_advise
+from prng.blum_blum_shub import BlumBlumShubGenerator, advise as blum_blum_shub_advise
+from prng.drbg import DRBGGenerator
+from prng.cavp import verify_fixtures
+from prng.combinators import COMBINATORS, BaysDurhamGenerator
+from prng.expression import ExpressionGenerator, parse_names, advise as expression_advise
+from prng.hull_dobell import advise as hull_dobell_advise
+from prng.lehmer_period import advise as lehmer_advise
+
+# Digit methods whose generation is watched for degeneration
+DEGENERATING = {MidSquareGenerator, MidProductGenerator}
+
+# Generators that can run side by side with Von Neumann's method from the same seed
+COMPARABLE = {MiddleSquareWeylGenerator}
+
+# Named parameter sets offered at the top of the form, keyed by generator class
+PRESETS = {
+    LaggedFibonacciGenerator: {
+        name: {"j": j, "k": k} for name, (j, k) in LAG_PRESETS.items()
+    },
+    LFSRGenerator: {
+        format_polynomial(polynomial): {"polynomial": polynomial}
+        for polynomial in map(polynomial_from_taps, PRIMITIVE_POLYNOMIALS.values())
+    }
+}
+
+@cache
+def cavp_report():
+    """Run the CAVP fixtures once, their result does not depend on the parameters."""
+    return "Known-answer tests (CAVP files): " + ", ".join(
+        f"{name} {'passed' if not mismatches else 'FAILED'} ({run} case(s))"
+        for name, (run, mismatches) in verify_fixtures().items()
+    )
+
+# Parameter advice shown under the form, keyed by generator class
+ADVISORS = {
+    MixedCongruentialGenerator: lambda params: hull_dobell_advise(
+        params['a'], params['c'], params['m']
+    ),
+    MultiplicativeCongruentialGenerator: lambda params: lehmer_advise(
+        params['x0'], params['a'], params['m']
+    ),
+    QuadraticCongruentialGenerator: lambda params: quadratic_advise(
+        params['d'], params['a'], params['c'], params['m']
+    ),
+    InversiveCongruentialGenerator: lambda params: inversive_advise(
+        params['x0'], params['a'], params['c'], params['p']
+    ),
+    LFSRGenerator: lambda params: lfsr_advise(params['polynomial'], params['s']),
+    BlumBlumShubGenerator: lambda params: blum_blum_shub_advise(
+        params['p'], params['q'], params['x0'], params['extraction']
+    ),
+    DRBGGenerator: lambda params: cavp_report(),
+    MersenneTwisterGenerator: lambda params: mersenne_twister_advise(params['x0'], params['seeding'])
+}
 
 class PRNGSelector:
     """Manages PRNG method selection and parameter forms."""
     
+    # Generators offered in the GUI, in display order
+    GENERATORS = [
+        MidSquareGenerator,
+        MidProductGenerator,
+        MiddleSquareWeylGenerator,
+        FibonacciGenerator,
+        LaggedFibonacciGenerator,
+        MixedCongruentialGenerator,
+        AdditiveCongruentialGenerator,
+        MultiplicativeCongruentialGenerator,
+        QuadraticCongruentialGenerator,
+        InversiveCongruentialGenerator,
+        WichmannHillGenerator,
+        LEcuyerGenerator,
+        MRG32k3aGenerator,
+        LFSRGenerator,
+        Xorshift32Generator,
+        Xorshift64Generator,
+        Xoshiro256StarStarGenerator,
+        SplitMix64Generator,
+        PCG32Generator,
+        MersenneTwisterGenerator,
+        ChaCha20Generator,
+        Philox4x32Generator,
+        BlumBlumShubGenerator,
+        DRBGGenerator
+    ]
+    
     # Define available PRNG methods and their parameters
     METHODS = {
-        "Von Neumann": {
-            "params": {
-                "n": "Number of random numbers",
-                "d": "Number of digits",
-                "x1": "Initial number"
-            }
-        },
-        "Fibonacci": {
-            "params": {
-                "n": "Number of random numbers",
-                "x0": "First seed",
-                "x1": "Second seed",
-                "m": "Module"
-            }
-        },
-        "Mixed Congruential": {
-            "params": {
-                "n": "Number of random numbers",
-                "x0": "Initial seed",
-                "a": "Multiplier",
-                "c": "Additive constant",
-                "m": "Module"
-            }
-        },
-        "Additive Congruential": {
-            "params": {
-                "n": "Number of random numbers",
-                "x0": "Initial seed",
-                "c": "Additive constant",
-                "m": "Module"
-            }
-        },
-        "Multiplicative Congruential": {
-            "params": {
-                "n": "Number of random numbers",
-                "x0": "Initial seed",
-                "a": "Multiplier",
-                "m": "Module"
-            }
+        generator.NAME: {
+            "generator": generator,
+            "advisor": ADVISORS.get(generator),
+            "degeneration": generator in DEGENERATING,
+            "comparison": generator in COMPARABLE,
+            "presets": PRESETS.get(generator),
+            "params": {"n": "Number of random numbers", **generator.PARAMS},
+            "form": None
         }
+        for generator in GENERATORS
     }
 
 class ParameterForm(QWidget):
-    """Base class for parameter input forms."""
+    """
+    Base class for parameter input forms.
+    
+    A parameter is described either by a string (a positive integer) or by
+    a dict with a "description" and optionally "choices", "text",
+    "default", "minimum" and "maximum". Integers beyond the spinbox range
+    are typed in a text field, in decimal or 0x hexadecimal; with "text"
+    the field takes free text instead of a number.
+    """
     
-    def __init__(self, params):
+    # Emitted whenever any parameter value changes
+    valueChanged = pyqtSignal()
+    
+    # Largest value a QSpinBox can hold
+    SPINBOX_LIMIT = 2 ** 31 - 1
+    
+    def __init__(self, params, presets=None):
         super().__init__()
         self.layout = QFormLayout(self)
-        self.spinboxes = {}
+        self.fields = {}
+        self.specs = {}
+        self.presets = presets or {}
+        
+        # Create preset selector, choosing one fills in its parameters
+        if self.presets:
+            preset_box = QComboBox()
+            preset_box.addItems(["Custom", *self.presets])
+            preset_box.currentTextChanged.connect(self.apply_preset)
+            self.layout.addRow("Preset:", preset_box)
         
-        # Create spinboxes for each parameter
-        for param, description in params.items():
-            spinbox = QSpinBox()
-            spinbox.setMinimum(1)
-            spinbox.setMaximum(999999)
-            self.spinboxes[param] = spinbox
-            self.layout.addRow(f"{param} ({description}):", spinbox)
+        # Create an input field for each parameter
+        for param, spec in params.items():
+            if isinstance(spec, str):
+                spec = {"description": spec}
+            field = self.create_field(spec)
+            self.fields[param] = field
+            self.specs[param] = spec
+            self.layout.addRow(f"{param} ({spec['description']}):", field)
+    
+    def create_field(self, spec):
+        """Create the input widget for one parameter specification."""
+        if "choices" in spec:
+            field = QComboBox()
+            field.addItems(spec["choices"])
+            if "default" in spec:
+                field.setCurrentText(spec["default"])
+            field.currentTextChanged.connect(self.valueChanged)
+            return field
+        
+        if spec.get("text"):
+            field = QLineEdit()
+            field.setText(spec.get("default", ""))
+            field.textChanged.connect(self.valueChanged)
+            return field
+        
+        maximum = spec.get("maximum", 999999)
+        if maximum > self.SPINBOX_LIMIT:
+            field = QLineEdit()
+            field.setValidator(QRegularExpressionValidator(
+                QRegularExpression("0[xX][0-9a-fA-F]+|[0-9]+")
+            ))
+            field.setText(str(spec.get("default", "")))
+            field.textChanged.connect(self.valueChanged)
+            return field
+        
+        field = QSpinBox()
+        field.setMinimum(spec.get("minimum", 1))
+        field.setMaximum(maximum)
+        if "default" in spec:
+            field.setValue(spec["default"])
+        field.valueChanged.connect(self.valueChanged)
+        return field
+    
+    def apply_preset(self, name):
+        """Fill in the parameters of the chosen preset."""
+        if name in self.presets:
+            self.set_values(self.presets[name])
+    
+    def set_values(self, values):
+        """Set parameter values by name."""
+        for param, value in values.items():
+            field = self.fields[param]
+            if isinstance(field, QComboBox):
+                field.setCurrentText(value)
+            elif isinstance(field, QLineEdit):
+                field.setText(str(value))
+            else:
+                field.setValue(value)
     
     def get_values(self):
         """Get current parameter values."""
-        return {param: spinbox.value() for param, spinbox in self.spinboxes.items()} 
\ No newline at end of file
+        values = {}
+        for param, field in self.fields.items():
+            if isinstance(field, QComboBox):
+                values[param] = field.currentText()
+            elif self.specs[param].get("text"):
+                values[param] = field.text()
+            elif isinstance(field, QLineEdit):
+                if not field.text():
+                    raise ValueError(f"{param} is required")
+                values[param] = int(field.text(), 0)
+            else:
+                values[param] = field.value()
+        return values
+
+def build_combination(combinator, k, first, second):
+    """
+    Build a combinator over two methods of PRNGSelector.METHODS.
+
+    Args:
+        combinator (str): Key of COMBINATORS
+        k (int): Table size of the shuffles
+        first, second (tuple): (method name, parameter values) of each generator
+    """
+    generators = [
+        PRNGSelector.METHODS[name]["generator"](**params) for name, params in (first, second)
+    ]
+    return COMBINATORS[combinator](*generators, k)
+
+class CombinationForm(QWidget):
+    """
+    Parameter form for a combinator: its own parameters plus two methods,
+    each one chosen from PRNGSelector.METHODS with its own ParameterForm.
+    """
+    
+    # Emitted whenever any parameter value changes
+    valueChanged = pyqtSignal()
+    
+    def __init__(self, params, presets=None):
+        super().__init__()
+        layout = QVBoxLayout(self)
+        self.own_form = ParameterForm(params, presets)
+        self.own_form.valueChanged.connect(self.valueChanged)
+        self.own_form.valueChanged.connect(self.update_visibility)
+        layout.addWidget(self.own_form)
+        
+        # Create a method selector and a stack of parameter forms for each generator
+        methods = [name for name, method in PRNGSelector.METHODS.items() if method["form"] is None]
+        self.selectors = {}
+        for role in ("first", "second"):
+            box = QWidget()
+            box_layout = QVBoxLayout(box)
+            box_layout.setContentsMargins(0, 0, 0, 0)
+            selector = QComboBox()
+            selector.addItems(methods)
+            stack = QStackedWidget()
+            for name in methods:
+                method = PRNGSelector.METHODS[name]
+                form = ParameterForm(
+                    {param: spec for param, spec in method["params"].items() if param != "n"},
+                    method["presets"]
+                )
+                form.valueChanged.connect(self.valueChanged)
+                stack.addWidget(form)
+            selector.currentIndexChanged.connect(stack.setCurrentIndex)
+            selector.currentIndexChanged.connect(self.valueChanged)
+            box_layout.addWidget(QLabel(f"{role.capitalize()} generator:"))
+            box_layout.addWidget(selector)
+            box_layout.addWidget(stack)
+            layout.addWidget(box)
+            self.selectors[role] = (box, selector, stack)
+        self.update_visibility()
+    
+    def update_visibility(self):
+        """The Bays-Durham shuffle only uses the first generator."""
+        combinator = self.own_form.fields["combinator"].currentText()
+        self.selectors["second"][0].setVisible(combinator != BaysDurhamGenerator.NAME)
+    
+    def get_values(self):
+        """Get current parameter values, each generator as (method name, values)."""
+        values = self.own_form.get_values()
+        for role, (_, selector, stack) in self.selectors.items():
+            values[role] = (selector.currentText(), stack.currentWidget().get_values())
+        return values
+
+# Combination of two of the methods above, with its own form
+PRNGSelector.METHODS["Combined generators"] = {
+    "generator": build_combination,
+    "advisor": None,
+    "degeneration": False,
+    "comparison": False,
+    "presets": None,
+    "params": {
+        "n": "Number of random numbers",
+        "combinator": {"description": "Combination", "choices": tuple(COMBINATORS)},
+        "k": {"description": "Table size (shuffles)", "default": 32}
+    },
+    "form": CombinationForm
+}
+
+class ExpressionForm(QWidget):
+    """
+    Parameter form for the custom expression generator.
+    
+    The constant names typed in the "constants" field get their own input
+    fields, rebuilt whenever the list of names changes.
+    """
+    
+    # Emitted whenever any parameter value changes
+    valueChanged = pyqtSignal()
+    
+    def __init__(self, params, presets=None):
+        super().__init__()
+        self.layout = QVBoxLayout(self)
+        self.own_form = ParameterForm(params, presets)
+        self.own_form.valueChanged.connect(self.valueChanged)
+        self.own_form.fields["constants"].textChanged.connect(self.update_constants)
+        self.layout.addWidget(self.own_form)
+        self.names = None
+        self.constants_form = None
+        self.update_constants()
+    
+    def update_constants(self):
+        """Rebuild the constant fields, keeping the values of the names that remain."""
+        try:
+            names = parse_names(self.own_form.fields["constants"].text())
+        except ValueError:
+            return
+        if names == self.names:
+            return
+        previous = {}
+        if self.constants_form is not None:
+            previous = {
+                name: value for name, value in self.constants_form.get_values().items()
+                if name in names
+            }
+            self.layout.removeWidget(self.constants_form)
+            self.constants_form.deleteLater()
+        self.names = names
+        self.constants_form = ParameterForm({
+            name: {"description": f"Constant {name}", "default": 1, "minimum": 0, "maximum": 2 ** 64}
+            for name in names
+        })
+        self.constants_form.set_values(previous)
+        self.constants_form.valueChanged.connect(self.valueChanged)
+        self.layout.addWidget(self.constants_form)
+        self.valueChanged.emit()
+    
+    def get_values(self):
+        """Get current parameter values, with the constants as a name -> value dict."""
+        values = self.own_form.get_values()
+        parse_names(values["constants"])
+        values["constants"] = self.constants_form.get_values()
+        return values
+
+# Recurrence typed by the user, with a form built from its constants
+PRNGSelector.METHODS[ExpressionGenerator.NAME] = {
+    "generator": ExpressionGenerator,
+    "advisor": lambda params: expression_advise(params['expression'], params['constants']),
+    "degeneration": False,
+    "comparison": False,
+    "presets": None,
+    "params": {"n": "Number of random numbers", **ExpressionGenerator.PARAMS},
+    "form": ExpressionForm
+}
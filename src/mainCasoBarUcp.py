@@ -5,6 +5,7 @@ from simpy import Environment, Resource
 import csv
 import os
 from typing import TypeVar, Any
+from prng.philox import Philox4x32Generator
 
 WriterType = TypeVar('WriterType', bound=csv.writer) # truco para obtener el tipo writer de la libreria de csv
 
@@ -135,5 +136,23 @@ def obtenerValorAleatorio(min: float, max: float):
     valorFinal= min + valorDeAdicion
     return valorFinal
 
+def crearFuncionAleatoriaConClave(clave: int) -> RandomGeneratorFunction:
+    """
+    Crea una función (min, max) que toma sus valores de Philox4x32-10 con la clave dada.
+    El valor i depende solo de (clave, i), asi que cada replicacion usa su propia
+    clave y nunca comparte valores con las demas, sin importar en que orden se corran.
+    """
+    generador = Philox4x32Generator(clave)
+    def obtenerValorConClave(min: float, max: float):
+        return min + (max - min) * generador.next_float()
+    return obtenerValorConClave
+
+def simular_replicaciones(cantidad: int, claveBase: int = 0, nombreArchivoCsv= "replicacion"):
+    """
+    Corre varias replicaciones de la simulación, la replicacion i con la clave claveBase + i.
+    """
+    for replicacion in range(cantidad):
+        simular_quiosco(crearFuncionAleatoriaConClave(claveBase + replicacion), f"{nombreArchivoCsv}_{replicacion}")
+
     
 simular_quiosco(obtenerValorAleatorio, "corrida")
@@ -0,0 +1,22 @@
+MASK32 = 2 ** 32 - 1
+MASK64 = 2 ** 64 - 1
+
+def rotl32(x, k):
+    """Rotate a 32-bit word left by k bits."""
+    return ((x << k) | (x >> (32 - k))) & MASK32
+
+def rotr32(x, k):
+    """Rotate a 32-bit word right by k bits."""
+    return ((x >> k) | (x << ((-k) & 31))) & MASK32
+
+def rotl64(x, k):
+    """Rotate a 64-bit word left by k bits."""
+    return ((x << k) | (x >> (64 - k))) & MASK64
+
+def unit_from_64(x):
+    """Map a 64-bit word to [0, 1) keeping its top 53 bits (a double's precision)."""
+    return (x >> 11) * 2.0 ** -53
+
+def unit_from_32_pair(a, b):
+    """Map two 32-bit words to [0, 1) with 53-bit precision (27 + 26 bits, as genrand_res53)."""
+    return ((a >> 5) * 67108864 + (b >> 6)) * 2.0 ** -53
@@ -0,0 +1,153 @@
+from math import gcd, lcm, log2
+
+from .generator import Generator
+from .number_theory import is_prime, multiplicative_order
+
+EXTRACTIONS = ("1 bit", "log2(log2 n) bits")
+
+def bits_per_step(n, extraction):
+    """Return how many low bits of each x_i are used: 1 or floor(log2(log2 n))."""
+    if extraction == EXTRACTIONS[0]:
+        return 1
+    return max(1, int(log2(log2(n))))
+
+def check_blum_prime(p):
+    """Raise ValueError unless p is a prime congruent to 3 mod 4."""
+    if not is_prime(p):
+        raise ValueError(f"{p} is not prime")
+    if p % 4 != 3:
+        raise ValueError(f"{p} is not a Blum prime, it must be 3 mod 4 (it is {p % 4})")
+
+def direct_access(x0, p, q, i):
+    """
+    Return x_i = x0^(2^i mod lambda(n)) mod n without computing x_1 ... x_{i-1}.
+
+    Since x0 is coprime with n = p * q, its powers repeat every
+    lambda(n) = lcm(p - 1, q - 1), so the exponent 2^i can be reduced first.
+    """
+    n = p * q
+    return pow(x0, pow(2, i, lcm(p - 1, q - 1)), n)
+
+class BlumBlumShubGenerator(Generator):
+    """
+    Blum Blum Shub: x_{i+1} = x_i^2 mod n, with n = p * q and p, q Blum primes.
+
+    The seed s gives x_0 = s^2 mod n. Each step outputs the low 1 or
+    log2(log2 n) bits of x_i, and each number gathers L of those bits,
+    the first one being the most significant. Predicting the next bit is
+    as hard as factoring n, which is why the generator is provably secure
+    but slow.
+    """
+
+    NAME = "Blum Blum Shub"
+    PARAMS = {
+        "p": {"description": "Blum prime (3 mod 4)", "default": 1001447, "maximum": 2 ** 64},
+        "q": {"description": "Blum prime (3 mod 4)", "default": 1006007, "maximum": 2 ** 64},
+        "x0": {"description": "Seed coprime with n", "default": 123456789, "maximum": 2 ** 128},
+        "extraction": {"description": "Bits per step", "choices": EXTRACTIONS},
+        "L": {"description": "Bits per number (L)", "default": 32, "maximum": 64}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, p, q, x0, extraction=EXTRACTIONS[0], L=32):
+        self.p = p
+        self.q = q
+        self.x0 = x0
+        self.extraction = extraction
+        self.L = L
+        super().__init__()
+
+    def validate(self):
+        check_blum_prime(self.p)
+        check_blum_prime(self.q)
+        if self.p == self.q:
+            raise ValueError("p and q must be different primes")
+        if not 1 < self.x0 < self.n:
+            raise ValueError(f"The seed must be between 2 and n - 1 = {self.n - 1}")
+        if gcd(self.x0, self.n) != 1:
+            raise ValueError(f"The seed must be coprime with n = {self.n} (gcd = {gcd(self.x0, self.n)})")
+        if self.x0 * self.x0 % self.n == 1:
+            raise ValueError("The seed squares to 1 mod n, the sequence would be constant")
+        if self.extraction not in EXTRACTIONS:
+            raise ValueError(f"Unknown extraction {self.extraction}, use one of {', '.join(EXTRACTIONS)}")
+        if not 1 <= self.L <= 64:
+            raise ValueError("L must be between 1 and 64")
+
+    @property
+    def n(self):
+        return self.p * self.q
+
+    @property
+    def bits(self):
+        return bits_per_step(self.n, self.extraction)
+
+    @property
+    def steps(self):
+        """Number of squarings needed for one L-bit number."""
+        return -(-self.L // self.bits)
+
+    @property
+    def modulus(self):
+        return 2 ** self.L
+
+    def initial_state(self):
+        return self.x0 * self.x0 % self.n
+
+    def transition(self, state):
+        for _ in range(self.steps):
+            state = state * state % self.n
+        return state
+
+    def output(self, state):
+        # Bits of x_{i+1} ... x_{i+steps}, the surplus beyond L is dropped
+        h = self.bits
+        value = 0
+        for _ in range(self.steps):
+            state = state * state % self.n
+            value = (value << h) | (state & ((1 << h) - 1))
+        return value >> (self.steps * h - self.L)
+
+    def next_int(self):
+        # The bits are read ahead of the state, which then moves past them
+        value = self.output(self._state)
+        self._state = self.transition(self._state)
+        return value
+
+    def state_at(self, i):
+        """Return x_i, counting from x_0 = seed^2 mod n, by direct access."""
+        return direct_access(self.initial_state(), self.p, self.q, i)
+
+    def jump(self, steps):
+        lam = lcm(self.p - 1, self.q - 1)
+        self._state = pow(self._state, pow(2, steps * self.steps, lam), self.n)
+
+    def period(self, max_steps=10**6):
+        """
+        Exact period, in numbers, from the orders involved.
+
+        x_0 is a quadratic residue, whose order t is odd for Blum primes,
+        so the x_i repeat with period ord_t(2) and there is no tail.
+        """
+        t = multiplicative_order(self.initial_state(), self.n)
+        lam = multiplicative_order(2, t)
+        return 0, lam // gcd(lam, self.steps)
+
+def advise(p, q, x0, extraction):
+    """Return a short report on the modulus, the extracted bits and the period."""
+    generator = BlumBlumShubGenerator(p, q, x0, extraction)
+    n = generator.n
+    t = multiplicative_order(generator.initial_state(), n)
+    report = (
+        f"n = {n} ({n.bit_length()} bits), {generator.bits} bit(s) per squaring\n"
+        f"x_i repeats every {multiplicative_order(2, t)} squarings"
+    )
+    if not all(is_prime((r - 1) // 2) for r in (p, q)):
+        report += "\nFor the longest periods choose p and q with (p - 1) / 2 and (q - 1) / 2 prime"
+    return report
+
+def generate_sequence(n, p, q, x0, extraction=EXTRACTIONS[0], L=32):
+    return BlumBlumShubGenerator(p, q, x0, extraction, L).generate(n)
+
+def stream_sequence(n, p, q, x0, extraction=EXTRACTIONS[0], L=32):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return BlumBlumShubGenerator(p, q, x0, extraction, L).stream(n)
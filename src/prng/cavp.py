@@ -0,0 +1,107 @@
+from pathlib import Path
+
+from .drbg import MECHANISMS
+
+# Local copies of the NIST CAVP DRBG vectors, laid out as in drbgvectors.zip
+# (no_reseed/, pr_false/, pr_true/, each with Hash_DRBG.rsp and HMAC_DRBG.rsp)
+FIXTURES = Path(__file__).parent / "fixtures" / "cavp"
+
+# Keys that appear more than once in a test case, once per generate call
+REPEATED_KEYS = ("AdditionalInput", "EntropyInputPR")
+
+def parse_rsp(path):
+    """
+    Parse a CAVP .rsp response file.
+
+    Args:
+        path (str or Path): File to read
+
+    Returns:
+        list: (section, case) pairs, where section holds the bracketed
+              header lines ("algorithm" plus e.g. "ReturnedBitsLen") and
+              case the KEY = value lines of one COUNT, with REPEATED_KEYS
+              collected into lists
+    """
+    cases = []
+    section = {}
+    case = None
+    for line in Path(path).read_text().splitlines():
+        line = line.strip()
+        if not line or line.startswith("#"):
+            continue
+        if line.startswith("["):
+            content = line.strip("[]")
+            if "=" in content:
+                key, value = (part.strip() for part in content.split("=", 1))
+                section[key] = value
+            else:
+                section = {"algorithm": content}
+            continue
+        key, value = (part.strip() for part in line.split("=", 1))
+        if key == "COUNT":
+            case = {key: int(value)}
+            cases.append((section, case))
+        elif key in REPEATED_KEYS:
+            case.setdefault(key, []).append(value)
+        else:
+            case[key] = value
+    return cases
+
+def run_case(mechanism, section, case):
+    """
+    Run one test case following the CAVP procedure.
+
+    Instantiate, reseed if the case has EntropyInputReseed, then make two
+    generate calls (each one preceded by a reseed with EntropyInputPR under
+    prediction resistance) and return the output of the second.
+    """
+    instantiate, reseed, generate = MECHANISMS[mechanism]
+    data = lambda key, index=None: bytes.fromhex(
+        case.get(key, [""] * 2)[index] if index is not None else case.get(key, "")
+    )
+    nbytes = int(section["ReturnedBitsLen"]) // 8
+
+    state = instantiate(data("EntropyInput"), data("Nonce"), data("PersonalizationString"))
+    if "EntropyInputReseed" in case:
+        state = reseed(state, data("EntropyInputReseed"), data("AdditionalInputReseed"))
+    for index in range(2):
+        additional = data("AdditionalInput", index)
+        if "EntropyInputPR" in case:
+            state = reseed(state, data("EntropyInputPR", index), additional)
+            additional = b""
+        output, state = generate(state, nbytes, additional)
+    return output
+
+def run_file(path, mechanism=None):
+    """
+    Run the SHA-256 test cases of a CAVP file (the other hashes are skipped).
+
+    Args:
+        path (str or Path): Hash_DRBG.rsp or HMAC_DRBG.rsp
+        mechanism (str): Key of MECHANISMS, taken from the file name by default
+
+    Returns:
+        tuple: (number of cases run, list of (COUNT, expected, actual) mismatches)
+    """
+    mechanism = mechanism or Path(path).stem
+    if mechanism not in MECHANISMS:
+        raise ValueError(f"Unknown mechanism {mechanism}, use one of {', '.join(MECHANISMS)}")
+    run = 0
+    mismatches = []
+    for section, case in parse_rsp(path):
+        if section.get("algorithm") != "SHA-256":
+            continue
+        run += 1
+        actual = run_case(mechanism, section, case).hex()
+        if actual != case["ReturnedBits"].lower():
+            mismatches.append((case["COUNT"], case["ReturnedBits"], actual))
+    return run, mismatches
+
+def verify_fixtures(directory=FIXTURES):
+    """Return file (relative to directory) -> (cases run, mismatches) for every .rsp file."""
+    directory = Path(directory)
+    return {
+        str(path.relative_to(directory)): run_file(path)
+        for path in sorted(directory.rglob("*.rsp"))
+        if path.stem in MECHANISMS
+    }
@@ -0,0 +1,87 @@
+from .counter_based import CounterBasedGenerator
+from .bits import MASK32, rotl32
+
+# "expand 32-byte k"
+CONSTANTS = (0x61707865, 0x3320646E, 0x79622D32, 0x6B206574)
+
+def quarter_round(state, a, b, c, d):
+    """Apply the ChaCha quarter round to four positions of the 16-word state list."""
+    state[a] = (state[a] + state[b]) & MASK32
+    state[d] = rotl32(state[d] ^ state[a], 16)
+    state[c] = (state[c] + state[d]) & MASK32
+    state[b] = rotl32(state[b] ^ state[c], 12)
+    state[a] = (state[a] + state[b]) & MASK32
+    state[d] = rotl32(state[d] ^ state[a], 8)
+    state[c] = (state[c] + state[d]) & MASK32
+    state[b] = rotl32(state[b] ^ state[c], 7)
+
+def _words(value, nbytes):
+    """Split an integer, read as big-endian bytes, into little-endian 32-bit words."""
+    data = value.to_bytes(nbytes, "big")
+    return [int.from_bytes(data[i:i + 4], "little") for i in range(0, nbytes, 4)]
+
+def chacha20_block(key, counter, nonce):
+    """
+    Return the 16 words of the ChaCha20 block function (RFC 7539, 2.3).
+
+    Args:
+        key (int): 256-bit key, its hexadecimal digits being the key bytes
+        counter (int): 32-bit block counter
+        nonce (int): 96-bit nonce, its hexadecimal digits being the nonce bytes
+    """
+    initial = [*CONSTANTS, *_words(key, 32), counter & MASK32, *_words(nonce, 12)]
+    state = list(initial)
+    for _ in range(10):
+        # Column rounds, then diagonal rounds
+        quarter_round(state, 0, 4, 8, 12)
+        quarter_round(state, 1, 5, 9, 13)
+        quarter_round(state, 2, 6, 10, 14)
+        quarter_round(state, 3, 7, 11, 15)
+        quarter_round(state, 0, 5, 10, 15)
+        quarter_round(state, 1, 6, 11, 12)
+        quarter_round(state, 2, 7, 8, 13)
+        quarter_round(state, 3, 4, 9, 14)
+    return tuple((x + y) & MASK32 for x, y in zip(state, initial))
+
+class ChaCha20Generator(CounterBasedGenerator):
+    """
+    ChaCha20 keystream (RFC 7539) read as 32-bit words.
+
+    Word i is word i mod 16 of the block with counter i // 16, so the
+    stream is fixed by (key, nonce) and has 2^32 blocks.
+    """
+
+    NAME = "ChaCha20"
+    PARAMS = {
+        "key": {"description": "Key (256 bits)", "default": 0, "minimum": 0, "maximum": 2 ** 256 - 1},
+        "nonce": {"description": "Nonce (96 bits)", "default": 0, "minimum": 0, "maximum": 2 ** 96 - 1},
+        "counter": {"description": "First block", "default": 0, "minimum": 0, "maximum": MASK32}
+    }
+    SEED_PARAMS = ("key",)
+    WORDS = 16
+
+    def __init__(self, key, nonce=0, counter=0):
+        self.key = key
+        self.nonce = nonce
+        self.counter = counter
+        super().__init__()
+
+    def validate(self):
+        if not 0 <= self.key < 2 ** 256:
+            raise ValueError("The key must fit in 256 bits")
+        if not 0 <= self.nonce < 2 ** 96:
+            raise ValueError("The nonce must fit in 96 bits")
+        if not 0 <= self.counter <= MASK32:
+            raise ValueError("The block counter must fit in 32 bits")
+
+    def block(self, counter):
+        return chacha20_block(self.key, counter, self.nonce)
+
+    def at(self, index):
+        if not 0 <= index < 2 ** 32 * self.WORDS:
+            raise ValueError("The keystream has 2^32 blocks of 16 words")
+        return super().at(index)
+
+    def period(self, max_steps=10**6):
+        # The 32-bit block counter wraps around
+        return 0, 2 ** 32 * self.WORDS
@@ -12,16 +12,35 @@ def chi_square_test(normalized_sequence, k=10):
     Returns:
         tuple: (chi_square_value, p_value, degrees_of_freedom)
     """
-    n = len(normalized_sequence)
-    expected_frequency = n / k
+    return chi_square_test_stream(normalized_sequence, k)
+
+def chi_square_test_stream(normalized_values, k=10):
+    """
+    Perform Chi-Square test counting the intervals online.
+
+    Only the k interval counters are kept, so any iterable (for example
+    stream_normaliser over a Generator.stream) can be tested in constant memory.
+    
+    Args:
+        normalized_values (iterable): Normalized random numbers
+        k (int): Number of intervals (default 10)
+    
+    Returns:
+        tuple: (chi_square_value, p_value, degrees_of_freedom)
+    """
+    n = 0
     observed_frequencies = [0] * k
     
     # Count frequencies in each interval
-    for num in normalized_sequence:
+    for num in normalized_values:
         interval = int(num * k)
         if interval == k:  # Handle edge case
             interval = k - 1
         observed_frequencies[interval] += 1
+        n += 1
+    if n == 0:
+        raise ValueError("The Chi-Square test needs at least one value")
+    expected_frequency = n / k
     
     # Calculate chi-square value
     chi_square = sum((obs - expected_frequency) ** 2 / expected_frequency 
@@ -31,4 +50,55 @@ def chi_square_test(normalized_sequence, k=10):
     degrees_of_freedom = k - 1
     p_value = 1 - chi2.cdf(chi_square, degrees_of_freedom)
     
-    return chi_square, p_value, degrees_of_freedom 
\ No newline at end of file
+    return chi_square, p_value, degrees_of_freedom
+
+def chi_square_classes(labels, observed, expected, min_expected=5):
+    """
+    Chi-square goodness of fit over given classes, merging the small ones.
+
+    Adjacent classes are merged, in order, until each group expects at
+    least min_expected values; what is left at the end joins the last group.
+
+    Args:
+        labels (list): Name of each class
+        observed (list): Observed count of each class
+        expected (list): Expected count of each class
+        min_expected (float): Smallest expected count allowed in a group
+
+    Returns:
+        tuple: (chi_square_value, p_value, degrees_of_freedom, table), table
+               being a list of (label, observed, expected) after merging
+
+    Raises:
+        ValueError: If fewer than two groups remain after merging
+    """
+    table = []
+    pending = None
+    for label, obs, exp in zip(labels, observed, expected):
+        if pending is None:
+            pending = [[label], obs, exp]
+        else:
+            pending[0].append(label)
+            pending[1] += obs
+            pending[2] += exp
+        if pending[2] >= min_expected:
+            table.append(pending)
+            pending = None
+    if pending is not None:
+        if table:
+            table[-1][0] += pending[0]
+            table[-1][1] += pending[1]
+            table[-1][2] += pending[2]
+        else:
+            table.append(pending)
+    if len(table) < 2:
+        raise ValueError(f"Not enough values: fewer than two classes expect {min_expected} or more")
+
+    table = [
+        (names[0] if len(names) == 1 else f"{names[0]} to {names[-1]}", obs, exp)
+        for names, obs, exp in table
+    ]
+    chi_square = sum((obs - exp) ** 2 / exp for _, obs, exp in table)
+    degrees_of_freedom = len(table) - 1
+    p_value = 1 - chi2.cdf(chi_square, degrees_of_freedom)
+    return chi_square, p_value, degrees_of_freedom, table
@@ -0,0 +1,204 @@
+from math import lcm
+
+from .generator import Generator
+
+# Bits kept from each value in [0, 1) when two generators are combined
+FRACTION_BITS = 53
+
+class WrapperGenerator(Generator):
+    """
+    Base class for generators built on top of other project generators.
+
+    The wrapped generators are driven through their own next_int(), so any
+    Generator works (including those that output before updating). The
+    state is a tuple holding their states, which keeps it immutable.
+    """
+
+    @staticmethod
+    def draw(generator, state):
+        """Return (next integer, next state) of generator from the given state."""
+        generator.set_state(state)
+        value = generator.next_int()
+        return value, generator.get_state()
+
+class BaysDurhamGenerator(WrapperGenerator):
+    """
+    Bays-Durham shuffle: the last output picks which of k stored values comes out next.
+
+    The chosen slot is refilled with a new value of the same generator,
+    which breaks up serial correlations of weak generators (Numerical
+    Recipes' ran1 uses it on a multiplicative congruential).
+    """
+
+    NAME = "Bays-Durham shuffle"
+    PARAMS = {"generator": "Generator to shuffle", "k": "Table size"}
+
+    def __init__(self, generator, k=32):
+        self.generator = generator
+        self.k = k
+        super().__init__()
+
+    def validate(self):
+        if self.k < 1:
+            raise ValueError("The table size k must be a positive number")
+
+    @property
+    def modulus(self):
+        return self.generator.modulus
+
+    def normalize(self, value):
+        return self.generator.normalize(value)
+
+    def initial_state(self):
+        self.generator.reset()
+        inner = self.generator.get_state()
+        table = []
+        for _ in range(self.k):
+            value, inner = self.draw(self.generator, inner)
+            table.append(value)
+        last, inner = self.draw(self.generator, inner)
+        # State: (wrapped state, table, last output)
+        return (inner, tuple(table), last)
+
+    def transition(self, state):
+        inner, table, last = state
+        j = int(self.k * self.generator.normalize(last))
+        value, inner = self.draw(self.generator, inner)
+        return (inner, table[:j] + (value,) + table[j + 1:], table[j])
+
+    def output(self, state):
+        return state[2]
+
+class MacLarenMarsagliaGenerator(WrapperGenerator):
+    """
+    MacLaren-Marsaglia shuffle (Knuth's Algorithm M): a second generator picks the slot.
+
+    The table holds k values of the first generator; at each step the
+    selector chooses the slot to output, which is then refilled with the
+    next value of the first generator.
+    """
+
+    NAME = "MacLaren-Marsaglia shuffle"
+    PARAMS = {"generator": "Generator to shuffle", "selector": "Generator that picks the slot", "k": "Table size"}
+
+    def __init__(self, generator, selector, k=64):
+        self.generator = generator
+        self.selector = selector
+        self.k = k
+        super().__init__()
+
+    def validate(self):
+        if self.k < 1:
+            raise ValueError("The table size k must be a positive number")
+        if self.generator is self.selector:
+            raise ValueError("The selector must be a different generator object")
+
+    @property
+    def modulus(self):
+        return self.generator.modulus
+
+    def normalize(self, value):
+        return self.generator.normalize(value)
+
+    def initial_state(self):
+        self.generator.reset()
+        self.selector.reset()
+        inner = self.generator.get_state()
+        table = []
+        for _ in range(self.k):
+            value, inner = self.draw(self.generator, inner)
+            table.append(value)
+        # State: (generator state, selector state, table, last output)
+        return (inner, self.selector.get_state(), tuple(table), None)
+
+    def transition(self, state):
+        inner, selector, table, _ = state
+        value, inner = self.draw(self.generator, inner)
+        choice, selector = self.draw(self.selector, selector)
+        j = int(self.k * self.selector.normalize(choice))
+        return (inner, selector, table[:j] + (value,) + table[j + 1:], table[j])
+
+    def output(self, state):
+        return state[3]
+
+class PairGenerator(WrapperGenerator):
+    """
+    Base class for the combination of two generators value by value.
+
+    Both values are mapped to [0, 1) by their own normalize() and kept
+    with FRACTION_BITS bits; subclasses define combine() on those integers.
+    """
+
+    PARAMS = {"first": "First generator", "second": "Second generator"}
+
+    def __init__(self, first, second):
+        self.first = first
+        self.second = second
+        super().__init__()
+
+    def validate(self):
+        if self.first is self.second:
+            raise ValueError("The two generators must be different objects")
+
+    @property
+    def modulus(self):
+        return 2 ** FRACTION_BITS
+
+    def combine(self, a, b):
+        raise NotImplementedError
+
+    def initial_state(self):
+        self.first.reset()
+        self.second.reset()
+        return (self.first.get_state(), self.second.get_state())
+
+    def step(self, state):
+        """Return (combined value, next state) from a pair of states."""
+        first, second = state
+        a, first = self.draw(self.first, first)
+        b, second = self.draw(self.second, second)
+        a = int(self.first.normalize(a) * 2 ** FRACTION_BITS)
+        b = int(self.second.normalize(b) * 2 ** FRACTION_BITS)
+        return self.combine(a, b), (first, second)
+
+    def transition(self, state):
+        return self.step(state)[1]
+
+    def output(self, state):
+        return self.step(state)[0]
+
+    def next_int(self):
+        # The output is taken from the state before the update, in a single step
+        value, self._state = self.step(self._state)
+        return value
+
+    def period(self, max_steps=10**6):
+        # The pair of states repeats at the lcm of both periods
+        periods = [self.first.period(max_steps), self.second.period(max_steps)]
+        if None in periods:
+            return None
+        return max(mu for mu, _ in periods), lcm(*(lam for _, lam in periods))
+
+class SumModOneGenerator(PairGenerator):
+    """u = (u1 + u2) mod 1, uniform as soon as one of the two terms is."""
+
+    NAME = "Sum mod 1"
+
+    def combine(self, a, b):
+        return (a + b) % 2 ** FRACTION_BITS
+
+class XorGenerator(PairGenerator):
+    """u = u1 XOR u2, bit by bit on the first FRACTION_BITS bits of each fraction."""
+
+    NAME = "XOR"
+
+    def combine(self, a, b):
+        return a ^ b
+
+# Combinator name -> factory(first, second, k)
+COMBINATORS = {
+    BaysDurhamGenerator.NAME: lambda first, second, k: BaysDurhamGenerator(first, k),
+    MacLarenMarsagliaGenerator.NAME: lambda first, second, k: MacLarenMarsagliaGenerator(first, second, k),
+    SumModOneGenerator.NAME: lambda first, second, k: SumModOneGenerator(first, second),
+    XorGenerator.NAME: lambda first, second, k: XorGenerator(first, second)
+}
@@ -0,0 +1,168 @@
+from math import lcm
+
+from .generator import Generator
+from .congruential_multiplicative import MultiplicativeCongruentialGenerator
+
+class CombinedMultiplicativeGenerator(Generator):
+    """
+    Base class for generators that combine several multiplicative congruentials.
+
+    Subclasses list their (a, m) pairs in COMPONENTS and the names of the
+    matching seed parameters in SEED_PARAMS, and define combine().
+    """
+
+    COMPONENTS = ()
+
+    def validate(self):
+        # Each component validates its own seed
+        self.components = [
+            MultiplicativeCongruentialGenerator(getattr(self, name), a, m)
+            for name, (a, m) in zip(self.SEED_PARAMS, self.COMPONENTS)
+        ]
+
+    def initial_state(self):
+        return tuple(component.initial_state() for component in self.components)
+
+    def transition(self, state):
+        return tuple(
+            component.transition(value)
+            for component, value in zip(self.components, state)
+        )
+
+    def output(self, state):
+        return self.combine(state)
+
+    def combine(self, state):
+        raise NotImplementedError
+
+    def jump(self, steps):
+        states = []
+        for component, value in zip(self.components, self._state):
+            component.set_state(value)
+            component.jump(steps)
+            states.append(component.get_state())
+        self._state = tuple(states)
+
+    def period(self, max_steps=10**6):
+        # The components cycle independently, so the state repeats at the lcm
+        periods = [component.period() for component in self.components]
+        return max(mu for mu, _ in periods), lcm(*(lam for _, lam in periods))
+
+class WichmannHillGenerator(CombinedMultiplicativeGenerator):
+    """
+    Wichmann-Hill (AS 183): u = (s1/30269 + s2/30307 + s3/30323) mod 1.
+
+    The sum is kept exact as an integer over 30269 * 30307 * 30323.
+    """
+
+    NAME = "Wichmann-Hill"
+    PARAMS = {
+        "s1": {"description": "First seed (1 to 30268)", "default": 1},
+        "s2": {"description": "Second seed (1 to 30306)", "default": 2},
+        "s3": {"description": "Third seed (1 to 30322)", "default": 3}
+    }
+    SEED_PARAMS = ("s1", "s2", "s3")
+    COMPONENTS = ((171, 30269), (172, 30307), (170, 30323))
+
+    def __init__(self, s1, s2, s3):
+        self.s1 = s1
+        self.s2 = s2
+        self.s3 = s3
+        super().__init__()
+
+    @property
+    def modulus(self):
+        return 30269 * 30307 * 30323
+
+    def combine(self, state):
+        s1, s2, s3 = state
+        return (s1 * 30307 * 30323 + s2 * 30269 * 30323 + s3 * 30269 * 30307) % self.modulus
+
+class LEcuyerGenerator(CombinedMultiplicativeGenerator):
+    """
+    L'Ecuyer (1988) combined generator: z = (s1 - s2) mod (m1 - 1), u = z / m1.
+
+    A zero difference is replaced by m1 - 1, so u is never 0.
+    """
+
+    NAME = "L'Ecuyer Combined"
+    PARAMS = {
+        "s1": {"description": "First seed (1 to 2147483562)", "default": 12345, "maximum": 2147483562},
+        "s2": {"description": "Second seed (1 to 2147483398)", "default": 67890, "maximum": 2147483398}
+    }
+    SEED_PARAMS = ("s1", "s2")
+    COMPONENTS = ((40014, 2147483563), (40692, 2147483399))
+
+    def __init__(self, s1, s2):
+        self.s1 = s1
+        self.s2 = s2
+        super().__init__()
+
+    @property
+    def modulus(self):
+        return 2147483563
+
+    def combine(self, state):
+        z = (state[0] - state[1]) % 2147483562
+        return z if z else 2147483562
+
+class MRG32k3aGenerator(Generator):
+    """
+    L'Ecuyer's MRG32k3a: two order-3 multiple recursive generators combined.
+
+        x1_n = (1403580 * x1_{n-2} - 810728 * x1_{n-3}) mod m1
+        x2_n = (527612 * x2_{n-1} - 1370589 * x2_{n-3}) mod m2
+        z_n = (x1_n - x2_n) mod m1, u_n = z_n / (m1 + 1) (m1 if z_n = 0)
+    """
+
+    NAME = "MRG32k3a"
+    M1 = 4294967087
+    M2 = 4294944443
+    PARAMS = {
+        "x10": {"description": "First component seed 1", "default": 12345, "maximum": M1 - 1},
+        "x11": {"description": "First component seed 2", "default": 12345, "maximum": M1 - 1},
+        "x12": {"description": "First component seed 3", "default": 12345, "maximum": M1 - 1},
+        "x20": {"description": "Second component seed 1", "default": 12345, "maximum": M2 - 1},
+        "x21": {"description": "Second component seed 2", "default": 12345, "maximum": M2 - 1},
+        "x22": {"description": "Second component seed 3", "default": 12345, "maximum": M2 - 1}
+    }
+    SEED_PARAMS = ("x10", "x11", "x12", "x20", "x21", "x22")
+
+    def __init__(self, x10=12345, x11=12345, x12=12345, x20=12345, x21=12345, x22=12345):
+        self.x10 = x10
+        self.x11 = x11
+        self.x12 = x12
+        self.x20 = x20
+        self.x21 = x21
+        self.x22 = x22
+        super().__init__()
+
+    def validate(self):
+        first = (self.x10, self.x11, self.x12)
+        second = (self.x20, self.x21, self.x22)
+        if not all(0 <= x < self.M1 for x in first) or not any(first):
+            raise ValueError(f"First component seeds must be below {self.M1} and not all 0")
+        if not all(0 <= x < self.M2 for x in second) or not any(second):
+            raise ValueError(f"Second component seeds must be below {self.M2} and not all 0")
+
+    @property
+    def modulus(self):
+        return self.M1 + 1
+
+    def initial_state(self):
+        # Oldest value first in each component
+        return (self.x10, self.x11, self.x12, self.x20, self.x21, self.x22)
+
+    def transition(self, state):
+        x10, x11, x12, x20, x21, x22 = state
+        x1 = (1403580 * x11 - 810728 * x10) % self.M1
+        x2 = (527612 * x22 - 1370589 * x20) % self.M2
+        return (x11, x12, x1, x21, x22, x2)
+
+    def output(self, state):
+        z = (state[2] - state[5]) % self.M1
+        return z if z else self.M1
+
+    def period(self, max_steps=10**6):
+        # Published period, about 2^191
+        return 0, (self.M1 ** 3 - 1) * (self.M2 ** 3 - 1) // 2
@@ -1,14 +1,49 @@
+from math import gcd
+
+from .generator import Generator
+
+class AdditiveCongruentialGenerator(Generator):
+    """Additive congruential method: x_{n+1} = (x_n + c) mod m."""
+
+    NAME = "Additive Congruential"
+    PARAMS = {
+        "x0": "Initial seed",
+        "c": "Additive constant",
+        "m": "Module"
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0, c, m):
+        self.x0 = x0
+        self.c = c
+        self.m = m
+        super().__init__()
+
+    def validate(self):
+        if not all(x > 0 for x in [self.x0, self.c]):
+            raise ValueError("x0 and c must be positive numbers")
+        if not self.m > max(self.x0, self.c):
+            raise ValueError("m must be greater than x0 and c")
+
+    @property
+    def modulus(self):
+        return self.m
+
+    def initial_state(self):
+        return self.x0
+
+    def transition(self, state):
+        return (state + self.c) % self.m
+
+    def jump(self, steps):
+        self._state = (self._state + steps * self.c) % self.m
+
+    def period(self, max_steps=10**6):
+        return 0, self.m // gcd(self.c, self.m)
+
 def generate_sequence(n, x0, c, m):
-    if not all(x > 0 for x in [x0, c]):
-        raise ValueError("x0 and c must be positive numbers")
-    if not m > max(x0, c):
-        raise ValueError("m must be greater than x0 and c")
-    
-    sequence = [x0]  # Start with seed
-    xn = x0
-    
-    for _ in range(n):
-        xn = (xn + c) % m
-        sequence.append(xn)
-    
-    return sequence[1:]
\ No newline at end of file
+    return AdditiveCongruentialGenerator(x0, c, m).generate(n)
+
+def stream_sequence(n, x0, c, m):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return AdditiveCongruentialGenerator(x0, c, m).stream(n)
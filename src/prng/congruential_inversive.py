@@ -0,0 +1,101 @@
+from .generator import Generator
+from .number_theory import is_prime, prime_factors
+
+def inverse(x, p):
+    """Modular inverse modulo the prime p, with the convention inverse(0) = 0."""
+    return pow(x, -1, p) if x % p else 0
+
+class InversiveCongruentialGenerator(Generator):
+    """Inversive congruential method: x_{n+1} = (a * inverse(x_n) + c) mod p, p prime."""
+
+    NAME = "Inversive Congruential"
+    PARAMS = {
+        "x0": "Initial seed",
+        "a": "Multiplier",
+        "c": "Additive constant",
+        "p": "Prime module"
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0, a, c, p):
+        self.x0 = x0
+        self.a = a
+        self.c = c
+        self.p = p
+        super().__init__()
+
+    def validate(self):
+        if not all(x > 0 for x in [self.x0, self.a, self.c]):
+            raise ValueError("x0, a, and c must be positive numbers")
+        if not self.p > max(self.x0, self.a, self.c):
+            raise ValueError("p must be greater than x0, a, and c")
+        if not is_prime(self.p):
+            raise ValueError("p must be a prime number")
+
+    @property
+    def modulus(self):
+        return self.p
+
+    def initial_state(self):
+        return self.x0
+
+    def transition(self, state):
+        return (self.a * inverse(state, self.p) + self.c) % self.p
+
+    def period(self, max_steps=10**6):
+        if is_primitive_polynomial(self.a, self.c, self.p):
+            return 0, self.p
+        return super().period(max_steps)
+
+def _multiply(u, v, a, c, p):
+    """Multiply u0 + u1*x by v0 + v1*x modulo x^2 - c*x - a over GF(p)."""
+    u0, u1 = u
+    v0, v1 = v
+    high = u1 * v1  # coefficient of x^2 = c*x + a
+    return ((u0 * v0 + high * a) % p, (u0 * v1 + u1 * v0 + high * c) % p)
+
+def _power_of_x(k, a, c, p):
+    """Return x^k modulo x^2 - c*x - a over GF(p)."""
+    result, base = (1, 0), (0, 1)
+    while k:
+        if k & 1:
+            result = _multiply(result, base, a, c, p)
+        base = _multiply(base, base, a, c, p)
+        k >>= 1
+    return result
+
+def is_primitive_polynomial(a, c, p):
+    """
+    Return True if x^2 - c*x - a is primitive over GF(p).
+
+    That is the case when x has order p^2 - 1 modulo the polynomial, and it
+    is a sufficient condition (Eichenauer and Lehn) for the inversive
+    generator to reach the full period p.
+    """
+    if a % p == 0:
+        return False
+    order = p * p - 1
+    if _power_of_x(order, a, c, p) != (1, 0):
+        return False
+    return all(_power_of_x(order // q, a, c, p) != (1, 0) for q in prime_factors(order))
+
+def advise(x0, a, c, p):
+    """Return a short report on the period reached by the given parameters."""
+    if not is_prime(p):
+        return "p must be a prime number"
+    if is_primitive_polynomial(a, c, p):
+        return f"x^2 - {c}x - {a} is primitive over GF({p}): full period {p} for every seed"
+    report = f"x^2 - {c}x - {a} is not primitive over GF({p}), the period may be shorter than {p}"
+    period = InversiveCongruentialGenerator(x0, a, c, p).period(10**5)
+    if period is not None:
+        report += f"\nPeriod from x0 = {x0}: {period[1]}"
+        if period[0]:
+            report += f" after a tail of {period[0]} values"
+    return report
+
+def generate_sequence(n, x0, a, c, p):
+    return InversiveCongruentialGenerator(x0, a, c, p).generate(n)
+
+def stream_sequence(n, x0, a, c, p):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return InversiveCongruentialGenerator(x0, a, c, p).stream(n)
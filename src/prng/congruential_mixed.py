@@ -1,14 +1,55 @@
+from math import gcd
+
+from .generator import Generator
+from .jump_ahead import affine_power, affine_period
+
+class MixedCongruentialGenerator(Generator):
+    """Mixed congruential method: x_{n+1} = (a * x_n + c) mod m."""
+
+    NAME = "Mixed Congruential"
+    PARAMS = {
+        "x0": "Initial seed",
+        "a": "Multiplier",
+        "c": "Additive constant",
+        "m": "Module"
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0, a, c, m):
+        self.x0 = x0
+        self.a = a
+        self.c = c
+        self.m = m
+        super().__init__()
+
+    def validate(self):
+        if not all(x > 0 for x in [self.x0, self.a, self.c]):
+            raise ValueError("x0, a, and c must be positive numbers")
+        if not self.m > max(self.x0, self.a, self.c):
+            raise ValueError("m must be greater than x0, a, and c")
+
+    @property
+    def modulus(self):
+        return self.m
+
+    def initial_state(self):
+        return self.x0
+
+    def transition(self, state):
+        return (self.a * state + self.c) % self.m
+
+    def jump(self, steps):
+        a, c = affine_power(self.a, self.c, self.m, steps)
+        self._state = (a * self._state + c) % self.m
+
+    def period(self, max_steps=10**6):
+        if gcd(self.a, self.m) == 1:
+            return 0, affine_period(self.a, self.c, self.m, self.x0)
+        return super().period(max_steps)
+
 def generate_sequence(n, x0, a, c, m):
-    if not all(x > 0 for x in [x0, a, c]):
-        raise ValueError("x0, a, and c must be positive numbers")
-    if not m > max(x0, a, c):
-        raise ValueError("m must be greater than x0, a, and c")
-    
-    sequence = [x0]  # Start with seed
-    xn = x0
-    
-    for _ in range(n):
-        xn = (a * xn + c) % m
-        sequence.append(xn)
-    
-    return sequence[1:]
\ No newline at end of file
+    return MixedCongruentialGenerator(x0, a, c, m).generate(n)
+
+def stream_sequence(n, x0, a, c, m):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return MixedCongruentialGenerator(x0, a, c, m).stream(n)
@@ -1,14 +1,48 @@
+from .generator import Generator
+from .lehmer_period import seed_period
+
+class MultiplicativeCongruentialGenerator(Generator):
+    """Multiplicative congruential method: x_{n+1} = (a * x_n) mod m."""
+
+    NAME = "Multiplicative Congruential"
+    PARAMS = {
+        "x0": "Initial seed",
+        "a": "Multiplier",
+        "m": "Module"
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0, a, m):
+        self.x0 = x0
+        self.a = a
+        self.m = m
+        super().__init__()
+
+    def validate(self):
+        if not all(x > 0 for x in [self.x0, self.a]):
+            raise ValueError("x0 and a must be positive numbers")
+        if not self.m > max(self.x0, self.a):
+            raise ValueError("m must be greater than x0 and a")
+
+    @property
+    def modulus(self):
+        return self.m
+
+    def initial_state(self):
+        return self.x0
+
+    def transition(self, state):
+        return (self.a * state) % self.m
+
+    def jump(self, steps):
+        self._state = pow(self.a, steps, self.m) * self._state % self.m
+
+    def period(self, max_steps=10**6):
+        return seed_period(self.x0, self.a, self.m)
+
 def generate_sequence(n, x0, a, m):
-    if not all(x > 0 for x in [x0, a]):
-        raise ValueError("x0 and a must be positive numbers")
-    if not m > max(x0, a):
-        raise ValueError("m must be greater than x0 and a")
-    
-    sequence = [x0]  # Start with seed
-    xn = x0
-    
-    for _ in range(n):
-        xn = (a * xn) % m
-        sequence.append(xn)
-    
-    return sequence[1:]
\ No newline at end of file
+    return MultiplicativeCongruentialGenerator(x0, a, m).generate(n)
+
+def stream_sequence(n, x0, a, m):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return MultiplicativeCongruentialGenerator(x0, a, m).stream(n)
@@ -0,0 +1,95 @@
+from math import gcd
+
+from .generator import Generator
+from .number_theory import factorize
+
+class QuadraticCongruentialGenerator(Generator):
+    """Quadratic congruential method: x_{n+1} = (d * x_n^2 + a * x_n + c) mod m."""
+
+    NAME = "Quadratic Congruential"
+    PARAMS = {
+        "x0": "Initial seed",
+        "d": "Quadratic coefficient",
+        "a": "Multiplier",
+        "c": "Additive constant",
+        "m": "Module"
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0, d, a, c, m):
+        self.x0 = x0
+        self.d = d
+        self.a = a
+        self.c = c
+        self.m = m
+        super().__init__()
+
+    def validate(self):
+        if not all(x > 0 for x in [self.x0, self.d, self.a, self.c]):
+            raise ValueError("x0, d, a, and c must be positive numbers")
+        if not self.m > max(self.x0, self.d, self.a, self.c):
+            raise ValueError("m must be greater than x0, d, a, and c")
+
+    @property
+    def modulus(self):
+        return self.m
+
+    def initial_state(self):
+        return self.x0
+
+    def transition(self, state):
+        return (self.d * state * state + self.a * state + self.c) % self.m
+
+    def period(self, max_steps=10**6):
+        if check_full_period(self.d, self.a, self.c, self.m)[0]:
+            return 0, self.m
+        return super().period(max_steps)
+
+def check_full_period(d, a, c, m):
+    """
+    Check Knuth's conditions (TAOCP 3.2.2, exercise 8) for period m.
+
+    x_{n+1} = (d * x_n^2 + a * x_n + c) mod m has period m if and only if:
+        1. gcd(c, m) = 1
+        2. d and a - 1 are divisible by every odd prime factor of m
+        3. d is even and d = a - 1 (mod 4) if m is divisible by 4,
+           d = a - 1 (mod 2) if m is divisible by 2
+        4. d != 3c (mod 9) if m is divisible by 9
+
+    Returns:
+        tuple: (full_period, failed_conditions)
+    """
+    failed = []
+    if gcd(c, m) != 1:
+        failed.append(f"gcd(c, m) = {gcd(c, m)}, c and m must be coprime")
+    odd_primes = [p for p in factorize(m) if p % 2]
+    missing = [p for p in odd_primes if d % p or (a - 1) % p]
+    if missing:
+        failed.append(
+            f"d and a - 1 must be divisible by the odd prime factor(s) "
+            f"{', '.join(str(p) for p in missing)} of m"
+        )
+    if m % 4 == 0:
+        if d % 2 or (d - (a - 1)) % 4:
+            failed.append("m is divisible by 4, so d must be even and d = a - 1 (mod 4)")
+    elif m % 2 == 0 and (d - (a - 1)) % 2:
+        failed.append("m is even, so d = a - 1 (mod 2) is required")
+    if m % 9 == 0 and (d - 3 * c) % 9 == 0:
+        failed.append("m is divisible by 9, so d must differ from 3c (mod 9)")
+    return not failed, failed
+
+def advise(d, a, c, m):
+    """Return a short report on the full-period conditions."""
+    if m < 2:
+        return "m must be at least 2"
+    full_period, failed = check_full_period(d, a, c, m)
+    if full_period:
+        return f"Knuth's conditions hold: full period {m} for every seed"
+    return "The period is shorter than m\n- " + "\n- ".join(failed)
+
+def generate_sequence(n, x0, d, a, c, m):
+    return QuadraticCongruentialGenerator(x0, d, a, c, m).generate(n)
+
+def stream_sequence(n, x0, d, a, c, m):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return QuadraticCongruentialGenerator(x0, d, a, c, m).stream(n)
@@ -0,0 +1,72 @@
+import copy
+
+from .generator import Generator
+from .bits import unit_from_32_pair
+
+class CounterBasedGenerator(Generator):
+    """
+    Base class for counter-based generators, where the i-th output is a pure
+    function of (key, i).
+
+    Subclasses implement block(counter), returning the 32-bit words of one
+    block, and set WORDS to their number. The state is the index of the
+    next output, so jumps are free and any value can be read with at().
+    The generator starts at block `counter`.
+    """
+
+    WORDS = None
+
+    @property
+    def modulus(self):
+        return 2 ** 32
+
+    def block(self, counter):
+        """Return the tuple of WORDS 32-bit words produced for one counter value."""
+        raise NotImplementedError
+
+    def at(self, index):
+        """Return output number index, counting from block 0, without touching the state."""
+        counter, word = divmod(index, self.WORDS)
+        # Consecutive outputs share a block, keep the last one
+        tag = (tuple(self.parameters().values()), counter)
+        cached = getattr(self, "_cached_block", None)
+        if cached is None or cached[0] != tag:
+            cached = (tag, self.block(counter))
+            self._cached_block = cached
+        return cached[1][word]
+
+    def initial_state(self):
+        return self.counter * self.WORDS
+
+    def transition(self, state):
+        return state + 1
+
+    def output(self, state):
+        return self.at(state)
+
+    def next_int(self):
+        # The output is taken from the state before the update
+        value = self.output(self._state)
+        self._state = self.transition(self._state)
+        return value
+
+    def next_double(self):
+        """Return the next two outputs as a float with 53-bit precision."""
+        # next_float() stays normalize(next_int()), a single 32-bit output
+        return unit_from_32_pair(self.next_int(), self.next_int())
+
+    def jump(self, steps):
+        self._state += steps
+
+    def with_key(self, key):
+        """
+        Return a copy that uses another key, starting again at its first block.
+
+        Different keys give independent streams, so simulation replications
+        can each take one key and never share values.
+        """
+        stream = copy.copy(self)
+        stream.key = key
+        stream.validate()
+        stream.reset()
+        return stream
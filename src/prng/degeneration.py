@@ -0,0 +1,111 @@
+from collections import namedtuple
+
+# What to do once the sequence degenerates
+CONTINUE = "continue"
+STOP = "stop"
+RESEED = "reseed"
+POLICIES = (CONTINUE, STOP, RESEED)
+
+# Kinds of degeneration
+ZERO = "collapse to zero"
+FIXED_POINT = "fixed point"
+SHORT_CYCLE = "short cycle"
+
+DegenerationEvent = namedtuple(
+    "DegenerationEvent", ["index", "kind", "value", "cycle_length", "action"]
+)
+DegenerationEvent.__doc__ = """
+Degeneration found while generating.
+
+    index (int): Position in the sequence where the repeating part starts
+    kind (str): ZERO, FIXED_POINT or SHORT_CYCLE
+    value (int): Value at index
+    cycle_length (int): Number of values that keep repeating
+    action (str): What was done about it (policy and new seed, if any)
+"""
+
+def digit_seed(d, base, attempt):
+    """Return a d-digit seed derived from base, different for each attempt."""
+    span = 9 * 10 ** (d - 1)
+    return 10 ** (d - 1) + (base + attempt * 7919) % span
+
+def _classify(cycle_values):
+    if all(value == 0 for value in cycle_values):
+        return ZERO
+    if len(cycle_values) == 1:
+        return FIXED_POINT
+    return SHORT_CYCLE
+
+def generate_monitored(generator, n, policy=CONTINUE, reseed=None):
+    """
+    Generate n values while watching for degeneration of a digit generator.
+
+    The generator state is tracked, so a collapse to zero, a fixed point
+    (e.g. 2500 for d = 4 in the middle-square method) or any cycle is
+    reported at the index where the repetition starts.
+
+    Args:
+        generator (Generator): Generator with d-digit seeds (mid-square, mid-product)
+        n (int): Number of values to generate
+        policy (str): CONTINUE keeps the repeating values, STOP returns the
+                      values generated before the repetition and RESEED
+                      restarts from a new seed
+        reseed (callable): attempt -> tuple of seed values, used with RESEED;
+                           defaults to digit_seed over the current seeds
+
+    Returns:
+        tuple: (sequence, events) with the generated values (exactly n unless
+               policy is STOP) and the list of DegenerationEvent found
+    """
+    if policy not in POLICIES:
+        raise ValueError(f"Unknown degeneration policy: {policy}")
+    if reseed is None:
+        reseed = lambda attempt: tuple(
+            digit_seed(generator.d, getattr(generator, name), attempt)
+            for name in generator.SEED_PARAMS
+        )
+
+    sequence = []
+    events = []
+    # State -> index of the value it produced; the seed state of the
+    # current segment maps to the index just before the segment starts
+    segment_start = 0
+    seen = {generator.get_state(): -1}
+    monitoring = True
+    while len(sequence) < n:
+        value = generator.next_int()
+        state = generator.get_state()
+        index = len(sequence)
+        if monitoring and state in seen:
+            start = seen[state]
+            cycle_length = index - start
+            if start < segment_start:
+                start = segment_start
+                cycle = sequence[start:] + [value]
+            else:
+                cycle = sequence[start:]
+            kind = _classify(cycle)
+            if kind == ZERO:
+                # The first zero can come before the repeating state, as in
+                # mid-product where (x, 0) leads to the fixed state (0, 0)
+                while start > segment_start and sequence[start - 1] == 0:
+                    start -= 1
+            if policy == STOP:
+                events.append(DegenerationEvent(start, kind, cycle[0], cycle_length, "stopped"))
+                break
+            if policy == RESEED:
+                seeds = reseed(len(events) + 1)
+                generator.seed(*seeds)
+                events.append(DegenerationEvent(
+                    start, kind, cycle[0], cycle_length,
+                    f"reseeded with {', '.join(str(seed) for seed in seeds)}"
+                ))
+                segment_start = index
+                seen = {generator.get_state(): index - 1}
+                continue
+            events.append(DegenerationEvent(start, kind, cycle[0], cycle_length, "continued"))
+            monitoring = False
+        if monitoring:
+            seen[state] = index
+        sequence.append(value)
+    return sequence, events
@@ -0,0 +1,199 @@
+import hashlib
+import hmac
+import os
+
+from .generator import Generator
+
+# SP 800-90A, table 2, for SHA-256
+OUTLEN = 32
+SEEDLEN = 440 // 8
+SECURITY_STRENGTH = 32
+RESEED_INTERVAL = 2 ** 48
+MAX_REQUEST_BYTES = 2 ** 19 // 8
+
+class ReseedRequired(Exception):
+    """Raised by a generate function once the reseed counter exceeds the reseed interval."""
+
+def _hash(*parts):
+    return hashlib.sha256(b"".join(parts)).digest()
+
+def _add(*values):
+    """Add byte strings (and ints) modulo 2^seedlen, as Hash_DRBG does with V."""
+    total = sum(int.from_bytes(v, "big") if isinstance(v, bytes) else v for v in values)
+    return (total % 2 ** (8 * SEEDLEN)).to_bytes(SEEDLEN, "big")
+
+def _check_request(state, nbytes, reseed_interval):
+    if not 0 < nbytes <= MAX_REQUEST_BYTES:
+        raise ValueError(f"A request must ask for 1 to {MAX_REQUEST_BYTES} bytes")
+    if state[-1] > reseed_interval:
+        raise ReseedRequired(f"The reseed counter exceeded the reseed interval ({reseed_interval})")
+
+# Hash_DRBG (SP 800-90A, 10.1.1); the state is (V, C, reseed_counter)
+
+def hash_df(data, nbytes):
+    """Hash derivation function: stretch data into nbytes bytes."""
+    temp = b""
+    counter = 1
+    while len(temp) < nbytes:
+        temp += _hash(bytes([counter]), (8 * nbytes).to_bytes(4, "big"), data)
+        counter += 1
+    return temp[:nbytes]
+
+def hash_instantiate(entropy, nonce, personalization=b""):
+    v = hash_df(entropy + nonce + personalization, SEEDLEN)
+    return (v, hash_df(b"\x00" + v, SEEDLEN), 1)
+
+def hash_reseed(state, entropy, additional=b""):
+    v = hash_df(b"\x01" + state[0] + entropy + additional, SEEDLEN)
+    return (v, hash_df(b"\x00" + v, SEEDLEN), 1)
+
+def hash_generate(state, nbytes, additional=b"", reseed_interval=RESEED_INTERVAL):
+    """Return (nbytes pseudo-random bytes, next state)."""
+    _check_request(state, nbytes, reseed_interval)
+    v, c, reseed_counter = state
+    if additional:
+        v = _add(v, _hash(b"\x02", v, additional))
+    # Hashgen
+    output = b""
+    data = v
+    while len(output) < nbytes:
+        output += _hash(data)
+        data = _add(data, 1)
+    v = _add(v, _hash(b"\x03", v), c, reseed_counter)
+    return output[:nbytes], (v, c, reseed_counter + 1)
+
+# HMAC_DRBG (SP 800-90A, 10.1.2); the state is (K, V, reseed_counter)
+
+def _hmac(key, *parts):
+    return hmac.new(key, b"".join(parts), hashlib.sha256).digest()
+
+def hmac_update(provided, key, value):
+    """HMAC_DRBG_Update: mix the provided data into (Key, V)."""
+    key = _hmac(key, value, b"\x00", provided)
+    value = _hmac(key, value)
+    if provided:
+        key = _hmac(key, value, b"\x01", provided)
+        value = _hmac(key, value)
+    return key, value
+
+def hmac_instantiate(entropy, nonce, personalization=b""):
+    key, value = hmac_update(entropy + nonce + personalization, b"\x00" * OUTLEN, b"\x01" * OUTLEN)
+    return (key, value, 1)
+
+def hmac_reseed(state, entropy, additional=b""):
+    key, value = hmac_update(entropy + additional, state[0], state[1])
+    return (key, value, 1)
+
+def hmac_generate(state, nbytes, additional=b"", reseed_interval=RESEED_INTERVAL):
+    """Return (nbytes pseudo-random bytes, next state)."""
+    _check_request(state, nbytes, reseed_interval)
+    key, value, reseed_counter = state
+    if additional:
+        key, value = hmac_update(additional, key, value)
+    output = b""
+    while len(output) < nbytes:
+        value = _hmac(key, value)
+        output += value
+    key, value = hmac_update(additional, key, value)
+    return output[:nbytes], (key, value, reseed_counter + 1)
+
+# Mechanism name -> (instantiate, reseed, generate)
+MECHANISMS = {
+    "Hash_DRBG": (hash_instantiate, hash_reseed, hash_generate),
+    "HMAC_DRBG": (hmac_instantiate, hmac_reseed, hmac_generate)
+}
+
+PREDICTION_RESISTANCE = ("Off", "On")
+
+class DRBGGenerator(Generator):
+    """
+    NIST SP 800-90A deterministic random bit generator, with SHA-256.
+
+    x0 and nonce are the entropy input and nonce of the instantiation,
+    so the same values always give the same output. Later reseeds (with
+    prediction resistance, or when the reseed counter passes the reseed
+    interval) take fresh entropy from entropy_source, os.urandom by
+    default. Each number is one 4-byte generate request.
+    """
+
+    NAME = "SP 800-90A DRBG"
+    PARAMS = {
+        "mechanism": {"description": "Mechanism", "choices": tuple(MECHANISMS)},
+        "x0": {"description": "Entropy input (256 bits)", "default": 2 ** 255 + 12345, "maximum": 2 ** 256 - 1},
+        "nonce": {"description": "Nonce (128 bits)", "default": 2 ** 127 + 54321, "minimum": 0, "maximum": 2 ** 128 - 1},
+        "prediction_resistance": {"description": "Prediction resistance", "choices": PREDICTION_RESISTANCE},
+        "reseed_interval": {"description": "Requests between reseeds", "default": RESEED_INTERVAL, "maximum": RESEED_INTERVAL}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, mechanism, x0, nonce, prediction_resistance="Off",
+                 reseed_interval=RESEED_INTERVAL, personalization=b"", entropy_source=os.urandom):
+        self.mechanism = mechanism
+        self.x0 = x0
+        self.nonce = nonce
+        self.prediction_resistance = prediction_resistance
+        self.reseed_interval = reseed_interval
+        self.personalization = personalization
+        self.entropy_source = entropy_source
+        super().__init__()
+
+    def validate(self):
+        if self.mechanism not in MECHANISMS:
+            raise ValueError(f"Unknown mechanism {self.mechanism}, use one of {', '.join(MECHANISMS)}")
+        if not 2 ** 255 <= self.x0 < 2 ** 256:
+            raise ValueError("The entropy input must have exactly 256 bits (security strength 256)")
+        if not 0 <= self.nonce < 2 ** 128:
+            raise ValueError("The nonce must fit in 128 bits")
+        if self.prediction_resistance not in PREDICTION_RESISTANCE:
+            raise ValueError(f"Prediction resistance must be one of {', '.join(PREDICTION_RESISTANCE)}")
+        if not 1 <= self.reseed_interval <= RESEED_INTERVAL:
+            raise ValueError("The reseed interval must be between 1 and 2^48")
+
+    @property
+    def modulus(self):
+        return 2 ** 32
+
+    def initial_state(self):
+        instantiate = MECHANISMS[self.mechanism][0]
+        entropy = self.x0.to_bytes(SECURITY_STRENGTH, "big")
+        # The state holds the working state and the last number
+        return (instantiate(entropy, self.nonce.to_bytes(16, "big"), self.personalization), None)
+
+    def _request(self, working_state, nbytes, additional, prediction_resistance):
+        """Generate function of SP 800-90A 9.3.1, reseeding when needed."""
+        _, reseed, generate = MECHANISMS[self.mechanism]
+        if prediction_resistance:
+            working_state = reseed(working_state, self.entropy_source(SECURITY_STRENGTH), additional)
+            additional = b""
+        try:
+            return generate(working_state, nbytes, additional, self.reseed_interval)
+        except ReseedRequired:
+            working_state = reseed(working_state, self.entropy_source(SECURITY_STRENGTH), additional)
+            return generate(working_state, nbytes, b"", self.reseed_interval)
+
+    def transition(self, state):
+        output, working_state = self._request(state[0], 4, b"", self.prediction_resistance == "On")
+        return (working_state, int.from_bytes(output, "big"))
+
+    def output(self, state):
+        return state[1]
+
+    def random_bytes(self, nbytes, additional=b"", prediction_resistance=False):
+        """Return nbytes bytes from one generate request, with optional additional input."""
+        prediction_resistance = prediction_resistance or self.prediction_resistance == "On"
+        output, working_state = self._request(self._state[0], nbytes, additional, prediction_resistance)
+        self._state = (working_state, self._state[1])
+        return output
+
+    def reseed(self, additional=b""):
+        """Reseed with fresh entropy from the entropy source."""
+        reseed = MECHANISMS[self.mechanism][1]
+        working_state = reseed(self._state[0], self.entropy_source(SECURITY_STRENGTH), additional)
+        self._state = (working_state, self._state[1])
+
+    @property
+    def reseed_counter(self):
+        return self._state[0][-1]
+
+    def period(self, max_steps=10**6):
+        raise ValueError("A DRBG has no computable period, its security rests on the hash function and reseeding")
@@ -0,0 +1,223 @@
+import ast
+import operator
+from math import gcd
+
+from .generator import Generator
+
+# Names the recurrence can use besides the declared constants
+VARIABLES = {
+    "x_n": "current value x_n",
+    "x_n_1": "previous value x_{n-1}",
+    "n": "index n",
+    "m": "module"
+}
+
+BINARY_OPERATORS = {
+    ast.Add: operator.add,
+    ast.Sub: operator.sub,
+    ast.Mult: operator.mul,
+    ast.FloorDiv: operator.floordiv,
+    ast.Mod: operator.mod,
+    ast.Pow: operator.pow,
+    ast.BitXor: operator.xor,
+    ast.BitAnd: operator.and_,
+    ast.BitOr: operator.or_,
+    ast.LShift: operator.lshift,
+    ast.RShift: operator.rshift
+}
+
+UNARY_OPERATORS = {
+    ast.USub: operator.neg,
+    ast.UAdd: operator.pos,
+    ast.Invert: operator.invert
+}
+
+FUNCTIONS = {
+    "abs": abs,
+    "min": min,
+    "max": max,
+    "gcd": gcd
+}
+
+# Limits that keep a typed expression from building huge integers
+MAX_EXPONENT = 64
+MAX_SHIFT = 256
+# Deepest expression tree, so that checking and evaluating it never hits the recursion limit
+MAX_DEPTH = 200
+# Largest intermediate result, enough for x_n**64 with m = 2^64 times another such value
+MAX_BITS = 8192
+
+def _result_bits(op, left, right):
+    """Return an upper bound of the bit length of left op right, for the operators that grow fast."""
+    if isinstance(op, ast.Pow):
+        return abs(left).bit_length() * right
+    if isinstance(op, ast.Mult):
+        return abs(left).bit_length() + abs(right).bit_length()
+    if isinstance(op, ast.LShift):
+        return abs(left).bit_length() + right
+    return 0
+
+def _check(node, names, depth=0):
+    """Raise ValueError unless every node of the tree is allowed."""
+    if depth > MAX_DEPTH:
+        raise ValueError(f"The expression is nested more than {MAX_DEPTH} levels deep")
+    if isinstance(node, ast.Expression):
+        _check(node.body, names, depth + 1)
+    elif isinstance(node, ast.BinOp):
+        if type(node.op) not in BINARY_OPERATORS:
+            symbol = "/" if isinstance(node.op, ast.Div) else type(node.op).__name__
+            raise ValueError(f"Operator {symbol} is not allowed (use // for integer division)")
+        _check(node.left, names, depth + 1)
+        _check(node.right, names, depth + 1)
+    elif isinstance(node, ast.UnaryOp):
+        if type(node.op) not in UNARY_OPERATORS:
+            raise ValueError(f"Operator {type(node.op).__name__} is not allowed")
+        _check(node.operand, names, depth + 1)
+    elif isinstance(node, ast.Constant):
+        if type(node.value) is not int:
+            raise ValueError(f"Only integer literals are allowed, not {node.value!r}")
+    elif isinstance(node, ast.Name):
+        if node.id not in names:
+            raise ValueError(f"Unknown name {node.id}, use {', '.join(sorted(names))}")
+    elif isinstance(node, ast.Call):
+        if not isinstance(node.func, ast.Name) or node.func.id not in FUNCTIONS or node.keywords:
+            raise ValueError(f"Only the functions {', '.join(FUNCTIONS)} can be called")
+        for argument in node.args:
+            _check(argument, names, depth + 1)
+    else:
+        raise ValueError(f"{type(node).__name__} is not allowed in an expression")
+
+def _evaluate(node, values):
+    if isinstance(node, ast.BinOp):
+        left = _evaluate(node.left, values)
+        right = _evaluate(node.right, values)
+        if isinstance(node.op, ast.Pow) and not 0 <= right <= MAX_EXPONENT:
+            raise ValueError(f"Exponents must be between 0 and {MAX_EXPONENT}")
+        if isinstance(node.op, (ast.LShift, ast.RShift)) and not 0 <= right <= MAX_SHIFT:
+            raise ValueError(f"Shifts must be between 0 and {MAX_SHIFT} bits")
+        if isinstance(node.op, (ast.FloorDiv, ast.Mod)) and right == 0:
+            raise ValueError("Division by zero in the expression")
+        if _result_bits(node.op, left, right) > MAX_BITS:
+            raise ValueError(f"Intermediate results are limited to {MAX_BITS} bits, reduce them with % m")
+        return BINARY_OPERATORS[type(node.op)](left, right)
+    if isinstance(node, ast.UnaryOp):
+        return UNARY_OPERATORS[type(node.op)](_evaluate(node.operand, values))
+    if isinstance(node, ast.Constant):
+        return node.value
+    if isinstance(node, ast.Name):
+        return values[node.id]
+    # ast.Call, already checked against FUNCTIONS
+    return FUNCTIONS[node.func.id](*(_evaluate(argument, values) for argument in node.args))
+
+def compile_expression(text, constants=()):
+    """
+    Parse an integer expression without using eval.
+
+    Only integer literals, the VARIABLES, the declared constants, the
+    arithmetic and bitwise operators and the FUNCTIONS are accepted.
+
+    Args:
+        text (str): Expression, e.g. "(a * x_n**2 + c) % m"
+        constants (iterable): Names of the declared constants
+
+    Returns:
+        tuple: (function values -> int, set of names the expression uses)
+
+    Raises:
+        ValueError: If the expression is malformed or uses anything else
+    """
+    try:
+        tree = ast.parse(text.strip(), mode="eval")
+    except SyntaxError as e:
+        raise ValueError(f"Invalid expression: {e.msg}")
+    except (RecursionError, MemoryError):
+        raise ValueError(f"The expression is nested more than {MAX_DEPTH} levels deep")
+    _check(tree, set(VARIABLES) | set(constants))
+    used = {node.id for node in ast.walk(tree) if isinstance(node, ast.Name)} - set(FUNCTIONS)
+    return (lambda values: _evaluate(tree.body, values)), used
+
+def check_name(name):
+    """Raise ValueError if name cannot be declared as a constant."""
+    if not name.isidentifier() or name in VARIABLES or name in FUNCTIONS:
+        raise ValueError(f"{name} cannot be used as a constant name")
+
+def parse_names(text):
+    """Return the constant names declared in a comma separated list."""
+    names = [name.strip() for name in text.split(",") if name.strip()]
+    for name in names:
+        check_name(name)
+    if len(set(names)) != len(names):
+        raise ValueError("Constant names must be different")
+    return names
+
+class ExpressionGenerator(Generator):
+    """
+    User defined recurrence: x_{n+1} = expression mod m.
+
+    The expression may use x_n, x_{n-1} (written x_n_1), n, m and the
+    declared constants. When it uses x_n_1 the sequence starts from x0
+    and x1, otherwise from x0 alone.
+    """
+
+    NAME = "Custom Expression"
+    PARAMS = {
+        "expression": {"description": "x_{n+1} =", "text": True, "default": "(a * x_n**2 + c) % m"},
+        "constants": {"description": "Constant names, comma separated", "text": True, "default": "a, c"},
+        "m": {"description": "Module", "default": 2 ** 16, "minimum": 2, "maximum": 2 ** 64},
+        "x0": {"description": "First seed", "default": 1, "minimum": 0, "maximum": 2 ** 64},
+        "x1": {"description": "Second seed (only with x_n_1)", "default": 1, "minimum": 0, "maximum": 2 ** 64}
+    }
+    SEED_PARAMS = ("x0", "x1")
+
+    def __init__(self, expression, constants, m, x0, x1=0):
+        self.expression = expression
+        self.constants = dict(constants)
+        self.m = m
+        self.x0 = x0
+        self.x1 = x1
+        super().__init__()
+
+    def validate(self):
+        for name in self.constants:
+            check_name(name)
+        self.function, used = compile_expression(self.expression, self.constants)
+        self.uses_previous = "x_n_1" in used
+        self.uses_index = "n" in used
+        if self.m < 2:
+            raise ValueError("m must be at least 2")
+        if not 0 <= self.x0 < self.m or not 0 <= self.x1 < self.m:
+            raise ValueError("The seeds must be between 0 and m - 1")
+
+    @property
+    def modulus(self):
+        return self.m
+
+    def initial_state(self):
+        # State: (x_{n-1}, x_n, n), with None for what the expression does not use
+        if self.uses_previous:
+            return (self.x0, self.x1, 1 if self.uses_index else None)
+        return (None, self.x0, 0 if self.uses_index else None)
+
+    def transition(self, state):
+        previous, current, index = state
+        value = self.function({
+            **self.constants, "x_n": current, "x_n_1": previous, "n": index, "m": self.m
+        }) % self.m
+        return (
+            current if self.uses_previous else None,
+            value,
+            index + 1 if self.uses_index else None
+        )
+
+    def output(self, state):
+        return state[1]
+
+def advise(expression, constants):
+    """Return what the recurrence depends on, or raise ValueError if it is invalid."""
+    _, used = compile_expression(expression, constants)
+    unused = [name for name in constants if name not in used]
+    seeds = "x0 and x1" if "x_n_1" in used else "x0"
+    report = f"The recurrence depends on {', '.join(sorted(used))} and starts from {seeds}"
+    if unused:
+        report += f"\nDeclared but not used: {', '.join(unused)}"
+    return report
@@ -1,13 +1,186 @@
+import operator
+
+from .generator import Generator
+from .congruential_mixed import MixedCongruentialGenerator
+from .congruential_multiplicative import MultiplicativeCongruentialGenerator
+
+class FibonacciGenerator(Generator):
+    """Fibonacci method: x_{n+1} = (x_n + x_{n-1}) mod m."""
+
+    NAME = "Fibonacci"
+    PARAMS = {
+        "x0": "First seed",
+        "x1": "Second seed",
+        "m": "Module"
+    }
+    SEED_PARAMS = ("x0", "x1")
+
+    def __init__(self, x0, x1, m):
+        self.x0 = x0
+        self.x1 = x1
+        self.m = m
+        super().__init__()
+
+    def validate(self):
+        if not all(x > 0 for x in [self.x0, self.x1]):
+            raise ValueError("x0 and x1 must be positive numbers")
+        if not self.m > max(self.x0, self.x1):
+            raise ValueError("m must be greater than x0 and x1")
+
+    @property
+    def modulus(self):
+        return self.m
+
+    def initial_state(self):
+        # State holds the last two values: (x_{n-1}, x_n)
+        return (self.x0, self.x1)
+
+    def transition(self, state):
+        previous, current = state
+        return (current, (current + previous) % self.m)
+
+    def output(self, state):
+        return state[1]
+
+# x_n = x_{n-j} op x_{n-k}
+OPERATIONS = {
+    "+": operator.add,
+    "-": operator.sub,
+    "*": operator.mul,
+    "xor": operator.xor
+}
+
+# Lags (j, k) with x^k + x^j + 1 primitive over GF(2), which give the
+# longest period for a modulus that is a power of two
+LAG_PRESETS = {
+    "1/2 (Fibonacci)": (1, 2),
+    "7/10": (7, 10),
+    "5/17": (5, 17),
+    "6/31": (6, 31),
+    "24/55": (24, 55),
+    "31/63": (31, 63),
+    "65/71": (65, 71),
+    "97/127": (97, 127),
+    "128/159": (128, 159),
+    "168/521": (168, 521),
+    "353/521": (353, 521),
+    "273/607": (273, 607),
+    "334/607": (334, 607)
+}
+
+# Congruential generators (with well known parameters) that fill the seed table
+SEEDERS = {
+    "Mixed Congruential": lambda x0: MixedCongruentialGenerator(x0, 1664525, 1013904223, 2 ** 32),
+    "Multiplicative Congruential": lambda x0: MultiplicativeCongruentialGenerator(x0, 48271, 2 ** 31 - 1)
+}
+
+class LaggedFibonacciGenerator(Generator):
+    """
+    Lagged Fibonacci method: x_n = (x_{n-j} op x_{n-k}) mod m, with j < k.
+
+    The first k values are drawn from a congruential generator seeded with
+    x0 and scaled to [0, m); when m is larger than the generator's modulus
+    each value concatenates several draws, so no low bits are left at 0.
+    """
+
+    NAME = "Lagged Fibonacci"
+    PARAMS = {
+        "j": {"description": "Short lag", "default": 24},
+        "k": {"description": "Long lag", "default": 55},
+        "operation": {"description": "Operation", "choices": tuple(OPERATIONS)},
+        "m": {"description": "Module", "default": 2 ** 32, "maximum": 2 ** 64},
+        "x0": "Seed of the table generator",
+        "seeder": {"description": "Table generator", "choices": tuple(SEEDERS)}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, j, k, operation, m, x0, seeder="Mixed Congruential"):
+        self.j = j
+        self.k = k
+        self.operation = operation
+        self.m = m
+        self.x0 = x0
+        self.seeder = seeder
+        super().__init__()
+
+    def validate(self):
+        if not 0 < self.j < self.k:
+            raise ValueError("Lags must satisfy 0 < j < k")
+        if self.operation not in OPERATIONS:
+            raise ValueError(f"Unknown operation {self.operation}, use one of {', '.join(OPERATIONS)}")
+        if self.m < 2:
+            raise ValueError("m must be at least 2")
+        if self.operation in ("*", "xor") and self.m & (self.m - 1):
+            raise ValueError(f"The {self.operation} operation needs m to be a power of 2")
+        if not isinstance(self.seeder, Generator) and self.seeder not in SEEDERS:
+            raise ValueError(f"Unknown table generator {self.seeder}")
+        if self.x0 <= 0:
+            raise ValueError("x0 must be a positive number")
+
+    @property
+    def modulus(self):
+        return self.m
+
+    def table_generator(self):
+        """Return the generator that fills the seed table, from its seed."""
+        if isinstance(self.seeder, Generator):
+            self.seeder.reset()
+            return self.seeder
+        return SEEDERS[self.seeder](self.x0)
+
+    def table_value(self, source):
+        """Draw one value in [0, m) from the table generator."""
+        # As many draws as needed to cover m, e.g. two 32-bit draws for m = 2^64
+        value, scale = 0, 1
+        while scale < self.m:
+            value = value * source.modulus + source.next_int()
+            scale *= source.modulus
+        return value * self.m // scale
+
+    def initial_state(self):
+        # State holds the last k values, oldest first
+        source = self.table_generator()
+        table = [self.table_value(source) for _ in range(self.k)]
+        if self.operation == "*":
+            # Products of odd numbers stay odd, an even value would reach 0
+            table = [value | 1 for value in table]
+        elif all(value % 2 == 0 for value in table):
+            # With all values even the low bit would stay 0 forever
+            table[0] |= 1
+        return tuple(table)
+
+    def transition(self, state):
+        value = OPERATIONS[self.operation](state[-self.j], state[-self.k]) % self.m
+        return state[1:] + (value,)
+
+    def output(self, state):
+        return state[-1]
+
+    def period(self, max_steps=10**6):
+        """
+        Known period for the presets with m = 2^e, otherwise Brent's algorithm.
+
+        With a primitive trinomial x^k + x^j + 1 the period is
+        (2^k - 1) * 2^(e-1) for + and -, 2^k - 1 for xor and
+        (2^k - 1) * 2^(e-3) for * (e >= 3).
+        """
+        e = self.m.bit_length() - 1
+        # The formulas need k > 2 for - and * (x_n = x_{n-1} - x_{n-2} has period 6)
+        known_lags = (self.j, self.k) in LAG_PRESETS.values() and (
+            self.k > 2 or self.operation in ("+", "xor")
+        )
+        if known_lags and self.m == 2 ** e:
+            if self.operation in ("+", "-"):
+                return 0, (2 ** self.k - 1) * 2 ** (e - 1)
+            if self.operation == "xor":
+                return 0, 2 ** self.k - 1
+            if e >= 3:
+                return 0, (2 ** self.k - 1) * 2 ** (e - 3)
+        return super().period(max_steps)
+
 def generate_sequence(n, x0, x1, m):
-    if not all(x > 0 for x in [x0, x1]):
-        raise ValueError("x0 and x1 must be positive numbers")
-    if not m > max(x0, x1):
-        raise ValueError("m must be greater than x0 and x1")
-    
-    sequence = [x0, x1]  # Start with both seeds
-    
-    for _ in range(n):
-        next_num = (sequence[-1] + sequence[-2]) % m
-        sequence.append(next_num)
-    
-    return sequence[2:]
\ No newline at end of file
+    return FibonacciGenerator(x0, x1, m).generate(n)
+
+def stream_sequence(n, x0, x1, m):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return FibonacciGenerator(x0, x1, m).stream(n)
@@ -0,0 +1,59 @@
+from collections import Counter
+
+from .chi_square import chi_square_classes
+
+def gap_lengths(normalized_sequence, alpha, beta):
+    """
+    Return the gaps between successive values that fall in [alpha, beta).
+
+    A gap is the number of values outside the interval between two
+    values inside it; the values before the first hit are not a gap.
+    """
+    gaps = []
+    gap = None
+    for u in normalized_sequence:
+        if alpha <= u < beta:
+            if gap is not None:
+                gaps.append(gap)
+            gap = 0
+        elif gap is not None:
+            gap += 1
+    return gaps
+
+def gap_test(normalized_sequence, alpha=0.0, beta=0.5, tail=None, min_expected=5):
+    """
+    Perform the gap test over the subinterval [alpha, beta) of [0, 1).
+
+    With p = beta - alpha, a gap has length i with probability
+    p (1 - p)^i (geometric law). Gaps 0 to t - 1 are counted one by one
+    and the longer ones go to the tail class ">= t", whose probability
+    is (1 - p)^t; small classes are then merged as in chi_square_classes.
+
+    Args:
+        normalized_sequence (list): List of normalized random numbers
+        alpha (float): Lower end of the subinterval
+        beta (float): Upper end of the subinterval (excluded)
+        tail (int): First gap length of the tail class; by default the
+                    longest gap observed
+        min_expected (float): Smallest expected count of a group of classes
+
+    Returns:
+        tuple: (chi_square_value, p_value, degrees_of_freedom, table), as chi_square_classes
+    """
+    if not 0 <= alpha < beta <= 1 or beta - alpha == 1:
+        raise ValueError("The subinterval must satisfy 0 <= alpha < beta <= 1 and be smaller than [0, 1)")
+    gaps = gap_lengths(normalized_sequence, alpha, beta)
+    if not gaps:
+        raise ValueError(f"Fewer than two values fall in [{alpha}, {beta})")
+    if tail is None:
+        tail = max(gaps)
+    if tail < 1:
+        raise ValueError("The tail class must start at a gap length of at least 1")
+
+    p = beta - alpha
+    total = len(gaps)
+    counts = Counter(gaps)
+    labels = [str(i) for i in range(tail)] + [f">= {tail}"]
+    observed = [counts[i] for i in range(tail)] + [sum(1 for gap in gaps if gap >= tail)]
+    expected = [total * p * (1 - p) ** i for i in range(tail)] + [total * (1 - p) ** tail]
+    return chi_square_classes(labels, observed, expected, min_expected)
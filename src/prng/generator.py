@@ -0,0 +1,119 @@
+from .period import find_period
+
+class Generator:
+    """
+    Base class for stateful pseudo-random number generators.
+
+    Subclasses describe their recurrence through initial_state() and
+    transition(); the state must be an immutable value (an int or a tuple)
+    so it can be saved, restored and compared.
+
+    Class attributes:
+        NAME (str): Human readable method name
+        PARAMS (dict): Parameter name -> description, in constructor order
+        SEED_PARAMS (tuple): Names of the parameters that act as seeds
+    """
+
+    NAME = None
+    PARAMS = {}
+    SEED_PARAMS = ()
+
+    def __init__(self):
+        self.validate()
+        self.reset()
+
+    def validate(self):
+        """Raise ValueError if the current parameters are invalid."""
+
+    @property
+    def modulus(self):
+        """Upper bound (exclusive) of the integers returned by next_int()."""
+        raise NotImplementedError
+
+    def initial_state(self):
+        """Return the state built from the seed parameters."""
+        raise NotImplementedError
+
+    def transition(self, state):
+        """Return the state that follows the given one."""
+        raise NotImplementedError
+
+    def output(self, state):
+        """Return the integer produced by the given state."""
+        return state
+
+    def parameters(self):
+        """Return the current parameter values keyed by name."""
+        return {name: getattr(self, name) for name in self.PARAMS}
+
+    def seed(self, *values):
+        """Set the seed parameters (in SEED_PARAMS order) and reset."""
+        if len(values) != len(self.SEED_PARAMS):
+            raise ValueError(f"{self.NAME} expects {len(self.SEED_PARAMS)} seed value(s)")
+        previous = {name: getattr(self, name) for name in self.SEED_PARAMS}
+        for name, value in zip(self.SEED_PARAMS, values):
+            setattr(self, name, value)
+        try:
+            self.validate()
+        except ValueError:
+            for name, value in previous.items():
+                setattr(self, name, value)
+            raise
+        self.reset()
+
+    def reset(self):
+        """Go back to the state given by the seed parameters."""
+        self._state = self.initial_state()
+
+    def get_state(self):
+        return self._state
+
+    def set_state(self, state):
+        self._state = state
+
+    def next_int(self):
+        """Advance the generator and return the next integer."""
+        self._state = self.transition(self._state)
+        return self.output(self._state)
+
+    def normalize(self, value):
+        """Map an integer output to [0, 1)."""
+        return value / self.modulus
+
+    def next_float(self):
+        """Advance the generator and return the next value normalized to [0, 1)."""
+        return self.normalize(self.next_int())
+
+    def jump(self, steps):
+        """
+        Advance the state by steps values without returning them.
+
+        This generic version walks the recurrence step by step; generators
+        with a closed form (the congruential ones) override it.
+        """
+        for _ in range(steps):
+            self._state = self.transition(self._state)
+
+    def period(self, max_steps=10**6):
+        """
+        Return (mu, lam), the tail and cycle length from the seed state.
+
+        Returns None when the cycle is longer than max_steps and cannot
+        be computed exactly.
+        """
+        return find_period(self, max_steps)
+
+    def stream(self, n=None):
+        """
+        Lazily yield the next n integers (forever if n is None).
+
+        Nothing is stored, so very long sequences use constant memory.
+        """
+        count = 0
+        while n is None or count < n:
+            yield self.next_int()
+            count += 1
+
+    def generate(self, n):
+        """Return a list with the next n integers."""
+        return list(self.stream(n))
@@ -0,0 +1,109 @@
+from math import gcd, lcm
+
+from .number_theory import factorize, radical
+
+def check_full_period(a, c, m):
+    """
+    Check the Hull-Dobell conditions for x_{n+1} = (a * x_n + c) mod m.
+
+    The mixed congruential generator has full period m for every seed
+    if and only if:
+        1. gcd(c, m) = 1
+        2. a - 1 is divisible by every prime factor of m
+        3. a - 1 is divisible by 4 if m is divisible by 4
+
+    Returns:
+        tuple: (full_period, failed_conditions) where failed_conditions is a
+               list describing each condition that does not hold
+    """
+    failed = []
+    if gcd(c, m) != 1:
+        failed.append(f"gcd(c, m) = {gcd(c, m)}, c and m must be coprime")
+    missing = [p for p in factorize(m) if (a - 1) % p != 0]
+    if missing:
+        failed.append(
+            f"a - 1 = {a - 1} is not divisible by the prime factor(s) "
+            f"{', '.join(str(p) for p in missing)} of m"
+        )
+    if m % 4 == 0 and (a - 1) % 4 != 0:
+        failed.append(f"m is divisible by 4 but a - 1 = {a - 1} is not")
+    return not failed, failed
+
+def potency(a, m):
+    """
+    Return the potency of the multiplier: the least s with (a - 1)^s = 0 (mod m).
+
+    Returns None when a - 1 is not divisible by every prime factor of m,
+    since then no such s exists. A potency below 5 gives visibly
+    non-random sequences even when the period is full.
+    """
+    b = (a - 1) % m
+    if b % radical(m) != 0:
+        return None
+    s = 1
+    power = b
+    while power != 0:
+        power = power * b % m
+        s += 1
+    return s
+
+def suggest_parameters(a, c, m, count=3):
+    """
+    Propose (a, c) pairs close to the given ones that achieve full period.
+
+    Multipliers are taken from a = 1 (mod lcm(rad(m), 4 if 4 | m)) and
+    increments from the values coprime to m, keeping 1 < a < m and 0 < c < m.
+
+    Returns:
+        list: Up to count tuples (a, c, potency), closest first; empty when
+              the only full-period multiplier is a = 1
+    """
+    step = lcm(radical(m), 4 if m % 4 == 0 else 1)
+    if step >= m:
+        return []
+
+    # Closest valid multipliers on both sides of a
+    below = a - (a - 1) % step
+    multipliers = sorted(
+        (x for x in range(below - 2 * step, below + 3 * step, step) if 1 < x < m),
+        key=lambda x: abs(x - a)
+    )[:count]
+
+    # Closest increments coprime to m, searching outwards from c
+    increments = []
+    distance = 0
+    while len(increments) < count and distance < m:
+        for y in sorted({c - distance, c + distance}):
+            if 0 < y < m and gcd(y, m) == 1:
+                increments.append(y)
+        distance += 1
+
+    pairs = sorted(
+        ((x, y) for x in multipliers for y in increments),
+        key=lambda pair: (abs(pair[0] - a) + abs(pair[1] - c), pair)
+    )
+    return [(x, y, potency(x, m)) for x, y in pairs[:count]]
+
+def advise(a, c, m):
+    """Return a short report on the full-period conditions and suggestions."""
+    if m < 2:
+        return "m must be at least 2"
+    full_period, failed = check_full_period(a, c, m)
+    if full_period:
+        s = potency(a, m)
+        report = f"Hull-Dobell: full period {m} for every seed (potency {s})"
+        if s < 5:
+            report += "\nA potency below 5 gives poorly mixed sequences"
+        return report
+    report = "Hull-Dobell: the period is shorter than m\n- " + "\n- ".join(failed)
+    suggestions = suggest_parameters(a, c, m)
+    if suggestions:
+        report += "\nNearby full-period parameters: " + ", ".join(
+            f"(a={x}, c={y}, potency {s})" for x, y, s in suggestions
+        )
+    else:
+        report += (
+            "\nFor this m only a = 1 gives full period; "
+            "choose an m with repeated prime factors, such as a power of 2"
+        )
+    return report
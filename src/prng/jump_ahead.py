@@ -0,0 +1,46 @@
+from math import gcd
+
+from .number_theory import carmichael, factorize
+
+def affine_power(a, c, m, k):
+    """
+    Return (A, C) such that applying x -> (a * x + c) mod m k times is x -> (A * x + C) mod m.
+
+    Uses exponentiation by squaring on the composition of affine maps,
+    so it takes O(log k) multiplications. With c = 0 it reduces to
+    A = a^k (multiplicative) and with a = 1 to C = k * c (additive).
+    """
+    result_a, result_c = 1 % m, 0
+    power_a, power_c = a % m, c % m
+    while k > 0:
+        if k & 1:
+            # result = power o result
+            result_a, result_c = power_a * result_a % m, (power_a * result_c + power_c) % m
+        # power = power o power
+        power_a, power_c = power_a * power_a % m, (power_a * power_c + power_c) % m
+        k >>= 1
+    return result_a, result_c
+
+def affine_period(a, c, m, x0):
+    """
+    Return the cycle length of x -> (a * x + c) mod m starting at x0.
+
+    Requires gcd(a, m) = 1, so the map is a permutation and x0 lies on its
+    cycle. The cycle length divides lambda(m) * m, and is found like a
+    multiplicative order: remove prime factors while x0 is still a fixed point.
+    """
+    if gcd(a, m) != 1:
+        raise ValueError(f"a = {a} and m = {m} are not coprime, the sequence has a tail")
+
+    def returns_to_start(k):
+        big_a, big_c = affine_power(a, c, m, k)
+        return (big_a * x0 + big_c) % m == x0 % m
+
+    lam = carmichael(m) * m
+    factors = dict(factorize(carmichael(m)))
+    for p, e in factorize(m).items():
+        factors[p] = factors.get(p, 0) + e
+    for p in factors:
+        while lam % p == 0 and returns_to_start(lam // p):
+            lam //= p
+    return lam
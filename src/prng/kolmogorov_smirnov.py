@@ -0,0 +1,66 @@
+from math import sqrt
+from scipy.stats import kstwo, kstwobign
+
+# Largest sample for which the exact distribution of D is used
+EXACT_LIMIT = 1000
+
+def ks_test(normalized_sequence, alpha=0.05):
+    """
+    Perform the Kolmogorov-Smirnov test of uniformity on [0, 1).
+
+    Unlike the chi-square test nothing is binned: the empirical CDF of
+    the sample is compared with F(u) = u at every value.
+
+    Args:
+        normalized_sequence (list): List of normalized random numbers
+        alpha (float): Significance level for the critical value
+
+    Returns:
+        tuple: (d_plus, d_minus, d, p_value, critical_value), using the exact
+               distribution of D for n <= EXACT_LIMIT and the asymptotic
+               Kolmogorov distribution of sqrt(n) * D above it
+    """
+    values = sorted(normalized_sequence)
+    n = len(values)
+    if n == 0:
+        raise ValueError("The KS test needs at least one value")
+
+    # D+ = max(i/n - u_(i)), D- = max(u_(i) - (i-1)/n)
+    d_plus = max((i + 1) / n - u for i, u in enumerate(values))
+    d_minus = max(u - i / n for i, u in enumerate(values))
+    d = max(d_plus, d_minus)
+
+    if n <= EXACT_LIMIT:
+        p_value = kstwo.sf(d, n)
+        critical_value = kstwo.isf(alpha, n)
+    else:
+        p_value = kstwobign.sf(sqrt(n) * d)
+        critical_value = kstwobign.isf(alpha) / sqrt(n)
+    return d_plus, d_minus, d, p_value, critical_value
+
+def ecdf_points(normalized_sequence):
+    """
+    Return the corners of the empirical CDF, ready for a step plot.
+
+    Returns:
+        tuple: (xs, ys) starting at (0, 0) and ending at (1, 1)
+    """
+    values = sorted(normalized_sequence)
+    n = len(values)
+    xs = [0.0] + values + [1.0]
+    ys = [0.0] + [(i + 1) / n for i in range(n)] + [1.0]
+    return xs, ys
+
+def largest_deviation(normalized_sequence):
+    """
+    Return (u, ecdf_below, ecdf_above) where the empirical CDF is furthest from u.
+
+    The empirical CDF jumps from ecdf_below to ecdf_above at u, and one of
+    the two is at distance D from the uniform CDF.
+    """
+    values = sorted(normalized_sequence)
+    n = len(values)
+    return max(
+        ((u, i / n, (i + 1) / n) for i, u in enumerate(values)),
+        key=lambda point: max(point[2] - point[0], point[0] - point[1])
+    )
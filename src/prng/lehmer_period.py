@@ -0,0 +1,106 @@
+from math import gcd
+
+from .number_theory import carmichael, factorize, is_prime, is_primitive_root, multiplicative_order
+
+def _coprime_part(m, a):
+    """Return the largest divisor of m that shares no prime factor with a."""
+    while gcd(m, a) != 1:
+        m //= gcd(m, a)
+    return m
+
+def seed_period(x0, a, m):
+    """
+    Return the exact tail and cycle length of x_{n+1} = (a * x_n) mod m from x0.
+
+    Since x_n = a^n * x0 (mod m), the cycle length is the order of a modulo
+    the part of m coprime to a, reduced by the factors already in x0. Prime
+    factors shared by a and m are absorbed after a few steps (the tail).
+
+    Returns:
+        tuple: (mu, lam) with the same meaning as period.find_period
+    """
+    coprime = _coprime_part(m, a)
+    lam = multiplicative_order(a % coprime, coprime // gcd(x0, coprime))
+
+    # The tail ends at the first n with a^n * x0 * (a^lam - 1) = 0 (mod m)
+    q = m // gcd(m, x0 * (pow(a, lam, m) - 1))
+    mu = 0
+    power = 1 % q
+    while power != 0:
+        power = power * a % q
+        mu += 1
+    return mu, lam
+
+def max_period(m):
+    """
+    Return the longest period any multiplier can reach modulo m.
+
+    That is lambda(m) for multipliers coprime to m: m - 1 for a prime m and
+    m / 4 for m = 2^k with k >= 3.
+    """
+    return carmichael(m)
+
+def _power_of_two_exponent(m):
+    """Return k if m = 2^k, else None."""
+    if m > 0 and m & (m - 1) == 0:
+        return m.bit_length() - 1
+    return None
+
+def is_max_period_multiplier(a, m):
+    """Return True if a reaches the longest period possible modulo m."""
+    if gcd(a, m) != 1:
+        return False
+    return multiplicative_order(a, m) == carmichael(m)
+
+def primitive_roots(p, count=10, start=2):
+    """
+    Enumerate primitive roots of the prime p, i.e. multipliers with period p - 1.
+
+    Args:
+        p (int): Prime modulus, e.g. 2^31 - 1
+        count (int): Number of roots to return
+        start (int): Smallest candidate to consider
+
+    Returns:
+        list: The first count primitive roots greater than or equal to start
+    """
+    if not is_prime(p):
+        raise ValueError(f"{p} is not prime, primitive roots are only listed for prime moduli")
+    roots = []
+    for g in range(max(start, 2), p):
+        if is_primitive_root(g, p):
+            roots.append(g)
+            if len(roots) == count:
+                break
+    return roots
+
+def advise(x0, a, m):
+    """Return a short report on the period reached by the given parameters."""
+    if m < 2:
+        return "m must be at least 2"
+    mu, lam = seed_period(x0, a, m)
+    report = f"Period from x0 = {x0}: {lam}"
+    if mu:
+        report += f" after a tail of {mu} values"
+    report += f" (maximum possible for m = {m}: {max_period(m)})"
+
+    k = _power_of_two_exponent(m)
+    if is_prime(m):
+        if is_primitive_root(a, m):
+            report += f"\na is a primitive root of {m}: every seed reaches period {m - 1}"
+        else:
+            report += (
+                f"\na is not a primitive root of {m}; nearby primitive roots: "
+                + ", ".join(str(g) for g in primitive_roots(m, 3, max(2, a - 50)))
+            )
+    elif k is not None and k >= 4:
+        # For m = 2^k the longest period m / 4 needs a = 3 or 5 (mod 8)
+        if a % 8 in (3, 5):
+            report += f"\na = {a % 8} (mod 8): odd seeds reach period {m // 4}"
+        else:
+            report += f"\nFor m = 2^{k} choose a = 3 or 5 (mod 8) and an odd seed to reach period {m // 4}"
+        if x0 % 2 == 0:
+            report += "\nx0 is even, so the period is shorter; choose an odd seed"
+    elif gcd(a, m) != 1:
+        report += f"\na and m share the factor(s) {', '.join(str(p) for p in factorize(gcd(a, m)))}"
+    return report
@@ -0,0 +1,223 @@
+from math import gcd
+
+from .generator import Generator
+from .number_theory import prime_factors
+
+CONFIGURATIONS = ("Fibonacci", "Galois")
+
+# Primitive polynomials over GF(2), one per degree, written as the exponents
+# of their non-constant terms: (16, 15, 13, 4) is x^16 + x^15 + x^13 + x^4 + 1.
+# Degrees 2 to 32 are the maximal-length taps of Xilinx XAPP 052.
+PRIMITIVE_POLYNOMIALS = {
+    2: (2, 1),
+    3: (3, 2),
+    4: (4, 3),
+    5: (5, 3),
+    6: (6, 5),
+    7: (7, 6),
+    8: (8, 6, 5, 4),
+    9: (9, 5),
+    10: (10, 7),
+    11: (11, 9),
+    12: (12, 6, 4, 1),
+    13: (13, 4, 3, 1),
+    14: (14, 5, 3, 1),
+    15: (15, 14),
+    16: (16, 15, 13, 4),
+    17: (17, 14),
+    18: (18, 11),
+    19: (19, 6, 2, 1),
+    20: (20, 17),
+    21: (21, 19),
+    22: (22, 21),
+    23: (23, 18),
+    24: (24, 23, 22, 17),
+    25: (25, 22),
+    26: (26, 6, 2, 1),
+    27: (27, 5, 2, 1),
+    28: (28, 25),
+    29: (29, 27),
+    30: (30, 6, 4, 1),
+    31: (31, 28),
+    32: (32, 22, 2, 1),
+    64: (64, 63, 61, 60),
+    89: (89, 51),
+    127: (127, 126)
+}
+
+def polynomial_from_taps(taps):
+    """Return the polynomial (bit i = coefficient of x^i) with the given taps and + 1."""
+    polynomial = 1
+    for tap in taps:
+        polynomial |= 1 << tap
+    return polynomial
+
+def taps_from_polynomial(polynomial):
+    """Return the exponents of the non-constant terms, highest first."""
+    return tuple(i for i in range(polynomial.bit_length() - 1, 0, -1) if polynomial >> i & 1)
+
+def format_polynomial(polynomial):
+    """Return the polynomial written as x^k + ... + 1."""
+    terms = [f"x^{i}" if i > 1 else "x" for i in taps_from_polynomial(polynomial)]
+    if polynomial & 1:
+        terms.append("1")
+    return " + ".join(terms) or "0"
+
+def _multiply_mod(u, v, polynomial):
+    """Multiply u by v modulo polynomial, all of them over GF(2)."""
+    degree = polynomial.bit_length() - 1
+    result = 0
+    while v:
+        if v & 1:
+            result ^= u
+        v >>= 1
+        u <<= 1
+        if u >> degree & 1:
+            u ^= polynomial
+    return result
+
+def _power_of_x(e, polynomial):
+    """Return x^e modulo polynomial over GF(2)."""
+    result, base = 1, _multiply_mod(2, 1, polynomial)
+    while e:
+        if e & 1:
+            result = _multiply_mod(result, base, polynomial)
+        base = _multiply_mod(base, base, polynomial)
+        e >>= 1
+    return result
+
+def is_primitive(polynomial):
+    """
+    Return True if the polynomial is primitive over GF(2).
+
+    A polynomial f of degree k is primitive when x has order 2^k - 1
+    modulo f, which is exactly when an LFSR with feedback f goes through
+    all 2^k - 1 non-zero states.
+    """
+    degree = polynomial.bit_length() - 1
+    if degree < 1 or not polynomial & 1:
+        return False
+    order = 2 ** degree - 1
+    if _power_of_x(order, polynomial) != 1:
+        return False
+    return all(_power_of_x(order // q, polynomial) != 1 for q in prime_factors(order))
+
+class LFSRGenerator(Generator):
+    """
+    Linear feedback shift register over GF(2) with Tausworthe output.
+
+    The register holds k bits, k being the degree of the feedback
+    polynomial. Each number is built from L consecutive output bits, the
+    first one being the most significant, and then the register moves s
+    bits forward: u_n = sum of b_{ns + j} * 2^-(j + 1) for j < L.
+    """
+
+    NAME = "LFSR (Tausworthe)"
+    PARAMS = {
+        "polynomial": {
+            "description": "Feedback polynomial (bit i = x^i)",
+            "default": polynomial_from_taps(PRIMITIVE_POLYNOMIALS[32]),
+            "minimum": 3,
+            "maximum": 2 ** 128
+        },
+        "configuration": {"description": "Configuration", "choices": CONFIGURATIONS},
+        "x0": {"description": "Initial register (not 0)", "default": 1, "maximum": 2 ** 128},
+        "L": {"description": "Bits per number (L)", "default": 32, "maximum": 64},
+        "s": {"description": "Bits between numbers (s)", "default": 32}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, polynomial, configuration, x0, L=32, s=32):
+        self.polynomial = polynomial
+        self.configuration = configuration
+        self.x0 = x0
+        self.L = L
+        self.s = s
+        super().__init__()
+
+    def validate(self):
+        if self.degree < 2 or not self.polynomial & 1:
+            raise ValueError("The polynomial must have degree at least 2 and constant term 1")
+        if self.configuration not in CONFIGURATIONS:
+            raise ValueError(f"Unknown configuration {self.configuration}, use one of {', '.join(CONFIGURATIONS)}")
+        if not 0 < self.x0 < 2 ** self.degree:
+            raise ValueError(f"x0 must be a non-zero register of {self.degree} bits")
+        if not 1 <= self.L <= 64:
+            raise ValueError("L must be between 1 and 64")
+        if self.s < 1:
+            raise ValueError("s must be a positive number")
+
+    @property
+    def degree(self):
+        return self.polynomial.bit_length() - 1
+
+    @property
+    def modulus(self):
+        return 2 ** self.L
+
+    def initial_state(self):
+        return self.x0
+
+    def shift(self, state):
+        """Return (output bit, next register) for a single step of the register."""
+        bit = state & 1
+        if self.configuration == "Fibonacci":
+            # Bit i holds b_{n+i}; b_{n+k} = sum of the coefficients of x^i times b_{n+i}
+            feedback = bin(state & self.polynomial).count("1") & 1
+            return bit, (state >> 1) | (feedback << (self.degree - 1))
+        # Galois: multiply the register by x^-1 modulo the polynomial
+        return bit, (state >> 1) ^ (self.polynomial >> 1 if bit else 0)
+
+    def transition(self, state):
+        for _ in range(self.s):
+            state = self.shift(state)[1]
+        return state
+
+    def output(self, state):
+        value = 0
+        for _ in range(self.L):
+            bit, state = self.shift(state)
+            value = (value << 1) | bit
+        return value
+
+    def next_int(self):
+        # The L bits are read from the register before it moves s bits forward
+        value = self.output(self._state)
+        self._state = self.transition(self._state)
+        return value
+
+    def next_bit(self):
+        """Advance the register one step and return the bit it shifted out."""
+        bit, self._state = self.shift(self._state)
+        return bit
+
+    def period(self, max_steps=10**6):
+        """With a primitive polynomial, (2^k - 1) / gcd(s, 2^k - 1), otherwise Brent's algorithm."""
+        if is_primitive(self.polynomial):
+            bits = 2 ** self.degree - 1
+            return 0, bits // gcd(self.s, bits)
+        return super().period(max_steps)
+
+def advise(polynomial, s):
+    """Return a short report on the feedback polynomial and the Tausworthe step."""
+    degree = polynomial.bit_length() - 1
+    if degree < 2 or not polynomial & 1:
+        return "The polynomial must have degree at least 2 and constant term 1"
+    name = format_polynomial(polynomial)
+    if not is_primitive(polynomial):
+        report = f"{name} is not primitive, the register does not go through all {2 ** degree - 1} non-zero states"
+        if degree in PRIMITIVE_POLYNOMIALS:
+            report += f"\nTry {format_polynomial(polynomial_from_taps(PRIMITIVE_POLYNOMIALS[degree]))}"
+        return report
+    report = f"{name} is primitive: maximal length 2^{degree} - 1 bits"
+    common = gcd(s, 2 ** degree - 1)
+    if common != 1:
+        report += f"\ngcd(s, 2^{degree} - 1) = {common}, the numbers repeat {common} times sooner; choose s coprime with it"
+    return report
+
+def generate_sequence(n, polynomial, configuration, x0, L=32, s=32):
+    return LFSRGenerator(polynomial, configuration, x0, L, s).generate(n)
+
+def stream_sequence(n, polynomial, configuration, x0, L=32, s=32):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return LFSRGenerator(polynomial, configuration, x0, L, s).stream(n)
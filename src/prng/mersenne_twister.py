@@ -0,0 +1,208 @@
+import random
+
+from .generator import Generator
+from .bits import MASK32, unit_from_32_pair
+
+N = 624
+M = 397
+MATRIX_A = 0x9908B0DF
+UPPER_MASK = 0x80000000
+LOWER_MASK = 0x7FFFFFFF
+
+def init_genrand(s):
+    """Return the 624-word table initialized from a 32-bit seed."""
+    mt = [s & MASK32]
+    for i in range(1, N):
+        mt.append((1812433253 * (mt[i - 1] ^ (mt[i - 1] >> 30)) + i) & MASK32)
+    return mt
+
+def init_by_array(key):
+    """Return the 624-word table initialized from a list of 32-bit words."""
+    mt = init_genrand(19650218)
+    i, j = 1, 0
+    for _ in range(max(N, len(key))):
+        mt[i] = ((mt[i] ^ ((mt[i - 1] ^ (mt[i - 1] >> 30)) * 1664525)) + key[j] + j) & MASK32
+        i += 1
+        j += 1
+        if i >= N:
+            mt[0] = mt[N - 1]
+            i = 1
+        if j >= len(key):
+            j = 0
+    for _ in range(N - 1):
+        mt[i] = ((mt[i] ^ ((mt[i - 1] ^ (mt[i - 1] >> 30)) * 1566083941)) - i) & MASK32
+        i += 1
+        if i >= N:
+            mt[0] = mt[N - 1]
+            i = 1
+    # MSB is 1, assuring a non-zero initial table
+    mt[0] = 0x80000000
+    return mt
+
+def twist(words):
+    """Return the next block of 624 words."""
+    mt = list(words)
+    for kk in range(N):
+        y = (mt[kk] & UPPER_MASK) | (mt[(kk + 1) % N] & LOWER_MASK)
+        mt[kk] = mt[(kk + M) % N] ^ (y >> 1) ^ (MATRIX_A if y & 1 else 0)
+    return tuple(mt)
+
+def temper(y):
+    """Tempering transform applied to each word on output."""
+    y ^= y >> 11
+    y ^= (y << 7) & 0x9D2C5680
+    y ^= (y << 15) & 0xEFC60000
+    y ^= y >> 18
+    return y
+
+# How x0 fills the table: as mt19937ar.c's init_genrand, or as Python's random.seed
+SEEDINGS = ("init_genrand", "random.seed")
+
+def python_seed_key(seed):
+    """Return the init_by_array key CPython's random.seed() uses for an int seed."""
+    seed = abs(seed)
+    key = []
+    while seed:
+        key.append(seed & MASK32)
+        seed >>= 32
+    return key or [0]
+
+class MersenneTwisterGenerator(Generator):
+    """
+    Matsumoto and Nishimura's MT19937, the generator behind Python's random.
+
+    Seeded with init_genrand(x0), with init_by_array as random.seed(x0)
+    does when seeding is "random.seed", or with init_by_array(key) when a
+    key (list of 32-bit words) is given. The state is the 624-word table
+    and the index of the next word to temper.
+    """
+
+    NAME = "Mersenne Twister"
+    PARAMS = {
+        "x0": {"description": "Seed (32 bits)", "default": 5489, "minimum": 0, "maximum": MASK32},
+        "seeding": {"description": "Seeding", "choices": SEEDINGS}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0=5489, key=None, seeding="init_genrand"):
+        self.x0 = x0
+        self.key = list(key) if key is not None else None
+        self.seeding = seeding
+        super().__init__()
+
+    def validate(self):
+        if not 0 <= self.x0 <= MASK32:
+            raise ValueError("x0 must fit in 32 bits")
+        if self.seeding not in SEEDINGS:
+            raise ValueError(f"Unknown seeding {self.seeding}, use one of {', '.join(SEEDINGS)}")
+        if self.key is not None:
+            if not self.key or not all(0 <= word <= MASK32 for word in self.key):
+                raise ValueError("The key must be a non-empty list of 32-bit words")
+
+    @classmethod
+    def from_python_seed(cls, seed):
+        """Build the generator random.seed(seed) would give, for an int seed."""
+        return cls(key=python_seed_key(seed))
+
+    @property
+    def modulus(self):
+        return 2 ** 32
+
+    def initial_state(self):
+        if self.key is not None:
+            words = init_by_array(self.key)
+        elif self.seeding == "random.seed":
+            words = init_by_array(python_seed_key(self.x0))
+        else:
+            words = init_genrand(self.x0)
+        return (tuple(words), N)
+
+    def transition(self, state):
+        words, index = state
+        if index >= N:
+            words, index = twist(words), 0
+        return (words, index + 1)
+
+    def output(self, state):
+        words, index = state
+        return temper(words[index - 1])
+
+    def next_double(self):
+        """Return the next two outputs as a 53-bit float (genrand_res53), the same as random.random()."""
+        # next_float() keeps the single-output normalize(next_int()) of every generator
+        return unit_from_32_pair(self.next_int(), self.next_int())
+
+    def period(self, max_steps=10**6):
+        return 0, 2 ** 19937 - 1
+
+    def export_state(self):
+        """Return the full state as 624 words plus the index, ready to save."""
+        words, index = self._state
+        return list(words) + [index]
+
+    def import_state(self, values):
+        """Restore a state produced by export_state()."""
+        if len(values) != N + 1:
+            raise ValueError(f"A Mersenne Twister state has {N} words and an index")
+        words, index = values[:N], values[N]
+        if not all(0 <= word <= MASK32 for word in words) or not 0 <= index <= N:
+            raise ValueError("Invalid Mersenne Twister state")
+        self._state = (tuple(words), index)
+
+    def to_python_state(self):
+        """Return the state in the format of random.getstate()."""
+        return (3, tuple(self.export_state()), None)
+
+    def from_python_state(self, state):
+        """Restore a state obtained from random.getstate()."""
+        version, internal, _ = state
+        if version != 3:
+            raise ValueError(f"Unsupported random state version {version}")
+        self.import_state(list(internal))
+
+def cross_check(python_random=None, n=1000):
+    """
+    Compare this implementation with CPython's random module.
+
+    The state of python_random (a random.Random, seeded with 5489 by
+    default) is imported with getstate(), then both produce n 32-bit words
+    and n floats. python_random itself is left untouched.
+
+    Returns:
+        list: (index, kind, expected, actual) for each mismatch, empty if identical
+    """
+    if python_random is None:
+        python_random = random.Random(5489)
+    reference = random.Random()
+    reference.setstate(python_random.getstate())
+    generator = MersenneTwisterGenerator()
+    generator.from_python_state(reference.getstate())
+
+    mismatches = []
+    for index in range(n):
+        expected, actual = reference.getrandbits(32), generator.next_int()
+        if expected != actual:
+            mismatches.append((index, "int", expected, actual))
+    for index in range(n):
+        expected, actual = reference.random(), generator.next_double()
+        if expected != actual:
+            mismatches.append((index, "float", expected, actual))
+    return mismatches
+
+def advise(x0, seeding):
+    """
+    Compare the generator built from x0 and seeding with random.seed(x0).
+
+    Returns:
+        str: Whether the first 1000 outputs match those of random.Random(x0)
+    """
+    generator = MersenneTwisterGenerator(x0, seeding=seeding)
+    reference = random.Random(x0)
+    for index in range(1000):
+        expected, actual = reference.getrandbits(32), generator.next_int()
+        if expected != actual:
+            return (
+                f"Output {index} differs from Python's random.seed({x0}): {actual} instead of {expected}"
+                + (" (init_genrand is not the seeding random.seed uses)" if seeding == "init_genrand" else "")
+            )
+    return f"The first 1000 outputs are identical to Python's random.seed({x0})"
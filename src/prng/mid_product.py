@@ -1,25 +1,54 @@
-def extract_middle_digits(malcolm, d): # TODO: update?
-    malcolm_str = str(malcolm)
-    if (len(malcolm_str) % 2) != (d % 2):
-        malcolm_str = '0' + malcolm_str
-    if len(malcolm_str) < d:
-        raise ValueError(f"extract_middle_digits: Number {malcolm} has fewer digits than required {d}")
-    middle_start = (len(malcolm_str) - d) // 2
-    return int(malcolm_str[middle_start:middle_start + d])
+from .generator import Generator
+
+def extract_middle_digits(product, d):
+    """Extract the middle d digits of product, read as a 2d-digit number padded with zeros."""
+    product_str = str(product).zfill(2 * d)
+    if len(product_str) > 2 * d:
+        raise ValueError(f"extract_middle_digits: Number {product} has more than {2 * d} digits")
+    middle_start = (len(product_str) - d) // 2
+    return int(product_str[middle_start:middle_start + d])
+
+class MidProductGenerator(Generator):
+    """Middle-product method: x_{n+1} = middle d digits of x_{n-1} * x_n."""
+
+    NAME = "Mid-Product"
+    PARAMS = {
+        "d": "Number of digits",
+        "x1": "First number",
+        "x2": "Second number"
+    }
+    SEED_PARAMS = ("x1", "x2")
+
+    def __init__(self, d, x1, x2):
+        self.d = d
+        self.x1 = x1
+        self.x2 = x2
+        super().__init__()
+
+    def validate(self):
+        if len(str(self.x1)) != self.d:
+            raise ValueError(f"Initial number x1 must have exactly {self.d} digits")
+        if len(str(self.x2)) != self.d:
+            raise ValueError(f"Initial number x2 must have exactly {self.d} digits")
+
+    @property
+    def modulus(self):
+        return 10 ** self.d
+
+    def initial_state(self):
+        # State holds the last two values: (x_{n-1}, x_n)
+        return (self.x1, self.x2)
+
+    def transition(self, state):
+        previous, current = state
+        return (current, extract_middle_digits(previous * current, self.d))
+
+    def output(self, state):
+        return state[1]
 
 def generate_sequence(n, d, x1, x2):
-    # if len(str(x1)) != d:
-    #     raise ValueError(f"Initial number x1 must have exactly {d} digits")
-    # if len(str(x2)) != d:
-    #     raise ValueError(f"Initial number x2 must have exactly {d} digits")
-    
-    series = [x1, x2]
-    
-    for _ in range(n):
-        product = x1 * x2
-        x3 = extract_middle_digits(product, d)
-        series.append(x3)
-        x1 = x2
-        x2 = x3
-    
-    return series[2:]
\ No newline at end of file
+    return MidProductGenerator(d, x1, x2).generate(n)
+
+def stream_sequence(n, d, x1, x2):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return MidProductGenerator(d, x1, x2).stream(n)
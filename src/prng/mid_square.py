@@ -1,18 +1,45 @@
 import math
 
+from .generator import Generator
+
 def extract_middle_digits(squared, d):
-    """Extract d digits from the middle of squared number, padding with zeros if needed."""
-    squared_str = str(squared)
-    # Pad with leading zeros if length is odd
-    if (len(squared_str) % 2) != (d % 2):
-        squared_str = '0' + squared_str
-    # Ensure squared has at least d digits
-    if len(squared_str) < d:
-        raise ValueError(f"Squared number {squared} has fewer digits than required {d}")
+    """Extract the middle d digits of squared, read as a 2d-digit number padded with zeros."""
+    squared_str = str(squared).zfill(2 * d)
+    if len(squared_str) > 2 * d:
+        raise ValueError(f"extract_middle_digits: Number {squared} has more than {2 * d} digits")
     # Calculate middle position
     middle_start = (len(squared_str) - d) // 2
     return int(squared_str[middle_start:middle_start + d])
 
+class MidSquareGenerator(Generator):
+    """Von Neumann's middle-square method: x_{n+1} = middle d digits of x_n^2."""
+
+    NAME = "Von Neumann"
+    PARAMS = {
+        "d": "Number of digits",
+        "x1": "Initial number"
+    }
+    SEED_PARAMS = ("x1",)
+
+    def __init__(self, d, x1):
+        self.d = d
+        self.x1 = x1
+        super().__init__()
+
+    def validate(self):
+        if len(str(self.x1)) != self.d:
+            raise ValueError(f"Initial number x1 must have exactly {self.d} digits")
+
+    @property
+    def modulus(self):
+        return 10 ** self.d
+
+    def initial_state(self):
+        return self.x1
+
+    def transition(self, state):
+        return extract_middle_digits(state * state, self.d)
+
 def generate_sequence(n, d, x1):
     """
     Generate a sequence of n random numbers using von Neumann's middle-square method.
@@ -25,16 +52,8 @@ def generate_sequence(n, d, x1):
     Returns:
         list: Sequence of generated random numbers
     """
-    # Validate input
-    if len(str(x1)) != d:
-        raise ValueError(f"Initial number x1 must have exactly {d} digits")
-    
-    series = [x1]  # Step 2: Append x1 to series
-    
-    for _ in range(n):  # Step 8: Repeat n times
-        squared = x1 * x1  # Step 3: Calculate square
-        x2 = extract_middle_digits(squared, d)  # Step 4: Get middle digits
-        series.append(x2)  # Step 5: Append to series
-        x1 = x2  # Step 7: Update x1
-    
-    return series[2:]
\ No newline at end of file
+    return MidSquareGenerator(d, x1).generate(n)
+
+def stream_sequence(n, d, x1):
+    """Lazily yield the same values as generate_sequence, one at a time."""
+    return MidSquareGenerator(d, x1).stream(n)
@@ -0,0 +1,75 @@
+from .generator import Generator
+from .bits import MASK32, MASK64
+from .degeneration import CONTINUE, generate_monitored
+from .mid_square import MidSquareGenerator
+
+# Weyl increment of Widynski's paper; any odd constant with a good mix of
+# zeros and ones works, the paper explains how to build more of them
+WEYL_CONSTANT = 0xB5AD4ECEDA1CE2A9
+
+class MiddleSquareWeylGenerator(Generator):
+    """
+    Widynski's middle-square Weyl sequence (msws): x = x^2 + (w += s), then swap halves.
+
+    Von Neumann's method collapses because the square alone can reach 0 or
+    a short cycle; adding the Weyl sequence w, whose period is 2^64, keeps
+    x from ever settling. The output is the low 32 bits of x after the
+    swap, i.e. the middle of the 64-bit square.
+    """
+
+    NAME = "Middle-Square Weyl"
+    PARAMS = {
+        "x0": {"description": "Initial x (64 bits)", "default": 0, "minimum": 0, "maximum": MASK64},
+        "w0": {"description": "Initial Weyl value (64 bits)", "default": 0, "minimum": 0, "maximum": MASK64},
+        "s": {"description": "Weyl increment (odd)", "default": WEYL_CONSTANT, "maximum": MASK64}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0=0, w0=0, s=WEYL_CONSTANT):
+        self.x0 = x0
+        self.w0 = w0
+        self.s = s
+        super().__init__()
+
+    def validate(self):
+        if not 0 <= self.x0 <= MASK64 or not 0 <= self.w0 <= MASK64:
+            raise ValueError("x0 and w0 must fit in 64 bits")
+        if not 0 < self.s <= MASK64 or self.s % 2 == 0:
+            raise ValueError("s must be an odd 64-bit number")
+
+    @property
+    def modulus(self):
+        return 2 ** 32
+
+    def initial_state(self):
+        return (self.x0, self.w0)
+
+    def transition(self, state):
+        x, w = state
+        w = (w + self.s) & MASK64
+        x = (x * x + w) & MASK64
+        return ((x >> 32) | (x << 32)) & MASK64, w
+
+    def output(self, state):
+        return state[0] & MASK32
+
+    def period(self, max_steps=10**6):
+        raise ValueError("msws is guaranteed a period of at least 2^64 (that of w), the exact cycle is unknown")
+
+def compare_with_mid_square(n, d, x1):
+    """
+    Run Von Neumann's method and msws side by side from the same seed.
+
+    msws starts with x0 = x1, w0 = 0 and the default increment. Both runs
+    are watched with degeneration.generate_monitored, so the collapse of
+    the middle-square method is reported next to the absence of one in msws.
+
+    Returns:
+        tuple: ((mid_square_values, mid_square_events), (weyl_values, weyl_events)),
+               each values list normalized to [0, 1)
+    """
+    runs = []
+    for generator in (MidSquareGenerator(d, x1), MiddleSquareWeylGenerator(x1)):
+        values, events = generate_monitored(generator, n, CONTINUE)
+        runs.append(([generator.normalize(value) for value in values], events))
+    return tuple(runs)
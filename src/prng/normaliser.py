@@ -2,4 +2,9 @@ def normaliser(sequence: list, m: int):
     normalized_sequence = []
     for num in sequence:
         normalized_sequence.append(num/m)
-    return normalized_sequence
\ No newline at end of file
+    return normalized_sequence
+
+def stream_normaliser(sequence, m: int):
+    """Lazily yield each number of sequence (any iterable) divided by m."""
+    for num in sequence:
+        yield num / m
@@ -0,0 +1,118 @@
+from math import gcd, lcm
+import random
+
+# Witnesses that make Miller-Rabin deterministic for n < 3.3 * 10^24
+_MILLER_RABIN_BASES = (2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41)
+
+def is_prime(n):
+    """Return True if n is prime (deterministic Miller-Rabin)."""
+    if n < 2:
+        return False
+    for p in _MILLER_RABIN_BASES:
+        if n % p == 0:
+            return n == p
+    d, s = n - 1, 0
+    while d % 2 == 0:
+        d //= 2
+        s += 1
+    for a in _MILLER_RABIN_BASES:
+        x = pow(a, d, n)
+        if x in (1, n - 1):
+            continue
+        for _ in range(s - 1):
+            x = x * x % n
+            if x == n - 1:
+                break
+        else:
+            return False
+    return True
+
+def _pollard_rho(n):
+    """Return a non-trivial factor of the composite number n."""
+    if n % 2 == 0:
+        return 2
+    while True:
+        c = random.randrange(1, n)
+        x = y = random.randrange(2, n)
+        d = 1
+        while d == 1:
+            x = (x * x + c) % n
+            y = (y * y + c) % n
+            y = (y * y + c) % n
+            d = gcd(abs(x - y), n)
+        if d != n:
+            return d
+
+def factorize(n):
+    """
+    Factor a positive integer.
+
+    Args:
+        n (int): Number to factor
+
+    Returns:
+        dict: Prime factor -> exponent
+    """
+    if n < 1:
+        raise ValueError("Only positive integers can be factored")
+    factors = {}
+    # Strip small primes first, Pollard's rho handles what is left
+    for p in range(2, 1000):
+        while n % p == 0:
+            factors[p] = factors.get(p, 0) + 1
+            n //= p
+    pending = [n] if n > 1 else []
+    while pending:
+        value = pending.pop()
+        if is_prime(value):
+            factors[value] = factors.get(value, 0) + 1
+        else:
+            divisor = _pollard_rho(value)
+            pending.extend([divisor, value // divisor])
+    return dict(sorted(factors.items()))
+
+def prime_factors(n):
+    """Return the sorted list of distinct prime factors of n."""
+    return list(factorize(n))
+
+def radical(n):
+    """Return the product of the distinct prime factors of n."""
+    result = 1
+    for p in prime_factors(n):
+        result *= p
+    return result
+
+def carmichael(n):
+    """Return the Carmichael function lambda(n), the exponent of (Z/nZ)*."""
+    result = 1
+    for p, e in factorize(n).items():
+        if p == 2 and e >= 3:
+            value = 2 ** (e - 2)
+        else:
+            value = (p - 1) * p ** (e - 1)
+        result = lcm(result, value)
+    return result
+
+def multiplicative_order(a, n):
+    """
+    Return the least k > 0 with a^k = 1 (mod n).
+
+    Raises:
+        ValueError: If a and n are not coprime (a has no order)
+    """
+    if gcd(a, n) != 1:
+        raise ValueError(f"{a} has no multiplicative order modulo {n}, they are not coprime")
+    if n == 1:
+        return 1
+    # The order divides lambda(n): remove prime factors while a^order stays 1
+    order = carmichael(n)
+    for p in factorize(order):
+        while order % p == 0 and pow(a, order // p, n) == 1:
+            order //= p
+    return order
+
+def is_primitive_root(g, p):
+    """Return True if g generates the multiplicative group modulo the prime p."""
+    if g % p == 0:
+        return False
+    return all(pow(g, (p - 1) // q, p) != 1 for q in prime_factors(p - 1))
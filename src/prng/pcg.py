@@ -0,0 +1,65 @@
+from .generator import Generator
+from .bits import MASK32, MASK64, rotr32, unit_from_32_pair
+
+MULTIPLIER = 6364136223846793005
+
+class PCG32Generator(Generator):
+    """
+    O'Neill's PCG32 (XSH RR): a 64-bit LCG whose state is permuted into 32 bits.
+
+    x0 is the initial state and seq selects the LCG increment
+    2 * seq + 1, giving 2^63 distinct streams.
+    """
+
+    NAME = "PCG32"
+    PARAMS = {
+        "x0": {"description": "Initial state (64 bits)", "default": 42, "minimum": 0, "maximum": MASK64},
+        "seq": {"description": "Stream selector (63 bits)", "default": 54, "minimum": 0, "maximum": 2 ** 63 - 1}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0, seq=54):
+        self.x0 = x0
+        self.seq = seq
+        super().__init__()
+
+    def validate(self):
+        if not 0 <= self.x0 <= MASK64:
+            raise ValueError("x0 must fit in 64 bits")
+        if not 0 <= self.seq < 2 ** 63:
+            raise ValueError("seq must fit in 63 bits")
+
+    @property
+    def increment(self):
+        return ((self.seq << 1) | 1) & MASK64
+
+    @property
+    def modulus(self):
+        return 2 ** 32
+
+    def initial_state(self):
+        # pcg32_srandom_r: one step from 0, add the seed, one more step
+        state = self.transition(0)
+        return self.transition((state + self.x0) & MASK64)
+
+    def transition(self, state):
+        return (state * MULTIPLIER + self.increment) & MASK64
+
+    def output(self, state):
+        xorshifted = (((state >> 18) ^ state) >> 27) & MASK32
+        return rotr32(xorshifted, state >> 59)
+
+    def next_int(self):
+        # The output is taken from the state before the update
+        value = self.output(self._state)
+        self._state = self.transition(self._state)
+        return value
+
+    def next_double(self):
+        """Return the next two outputs as a float with 53-bit precision."""
+        # next_float() stays normalize(next_int()), a single 32-bit output
+        return unit_from_32_pair(self.next_int(), self.next_int())
+
+    def period(self, max_steps=10**6):
+        # The LCG satisfies Hull-Dobell for m = 2^64
+        return 0, 2 ** 64
@@ -0,0 +1,46 @@
+def find_period(generator, max_steps=10**6):
+    """
+    Find the tail length and cycle length of a generator using Brent's algorithm.
+
+    Works on the generator states, starting from the state given by its seed
+    parameters, so generators whose state holds several values (Fibonacci,
+    mid-product) are handled correctly.
+
+    Args:
+        generator (Generator): Generator to analyse (its own state is not modified)
+        max_steps (int): Give up once the cycle is known to be longer than this
+
+    Returns:
+        tuple: (mu, lam) where mu is the number of states before the cycle
+               starts and lam is the cycle length, or None if the cycle
+               length exceeds max_steps
+    """
+    transition = generator.transition
+    start = generator.initial_state()
+
+    # Find the cycle length: the hare moves ahead while the tortoise waits
+    # at successive powers of two
+    power = lam = 1
+    tortoise = start
+    hare = transition(start)
+    while tortoise != hare:
+        if power == lam:
+            if power > max_steps:
+                return None
+            tortoise = hare
+            power *= 2
+            lam = 0
+        hare = transition(hare)
+        lam += 1
+
+    # Find the tail length: move two pointers lam apart until they meet
+    tortoise = hare = start
+    for _ in range(lam):
+        hare = transition(hare)
+    mu = 0
+    while tortoise != hare:
+        tortoise = transition(tortoise)
+        hare = transition(hare)
+        mu += 1
+
+    return mu, lam
@@ -0,0 +1,66 @@
+from .counter_based import CounterBasedGenerator
+from .bits import MASK32
+
+# Multipliers and Weyl key increments of Philox4x32 (Salmon et al., 2011)
+M0 = 0xD2511F53
+M1 = 0xCD9E8D57
+W0 = 0x9E3779B9
+W1 = 0xBB67AE85
+
+def philox4x32(counter, key, rounds=10):
+    """
+    Return the four 32-bit words Philox4x32 produces for a counter and key.
+
+    Args:
+        counter (tuple): Four 32-bit words, the first one being the lowest
+        key (tuple): Two 32-bit words
+        rounds (int): Number of rounds, 10 in Philox4x32-10
+    """
+    c0, c1, c2, c3 = counter
+    k0, k1 = key
+    for round_number in range(rounds):
+        if round_number:
+            k0 = (k0 + W0) & MASK32
+            k1 = (k1 + W1) & MASK32
+        product0 = M0 * c0
+        product1 = M1 * c2
+        c0, c1, c2, c3 = (
+            (product1 >> 32) ^ c1 ^ k0, product1 & MASK32,
+            (product0 >> 32) ^ c3 ^ k1, product0 & MASK32
+        )
+    return c0, c1, c2, c3
+
+class Philox4x32Generator(CounterBasedGenerator):
+    """
+    Philox4x32-10 counter-based generator (Random123).
+
+    The 128-bit counter i gives outputs 4i to 4i + 3; the 64-bit key
+    selects the stream, its low word being the first key word.
+    """
+
+    NAME = "Philox4x32-10"
+    PARAMS = {
+        "key": {"description": "Key (64 bits)", "default": 0, "minimum": 0, "maximum": 2 ** 64 - 1},
+        "counter": {"description": "First counter (128 bits)", "default": 0, "minimum": 0, "maximum": 2 ** 128 - 1}
+    }
+    SEED_PARAMS = ("key",)
+    WORDS = 4
+
+    def __init__(self, key, counter=0):
+        self.key = key
+        self.counter = counter
+        super().__init__()
+
+    def validate(self):
+        if not 0 <= self.key < 2 ** 64:
+            raise ValueError("The key must fit in 64 bits")
+        if not 0 <= self.counter < 2 ** 128:
+            raise ValueError("The counter must fit in 128 bits")
+
+    def block(self, counter):
+        counter %= 2 ** 128
+        words = tuple(counter >> (32 * i) & MASK32 for i in range(4))
+        return philox4x32(words, (self.key & MASK32, self.key >> 32))
+
+    def period(self, max_steps=10**6):
+        return 0, 2 ** 128 * self.WORDS
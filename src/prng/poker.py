@@ -0,0 +1,70 @@
+from collections import Counter
+
+from .chi_square import chi_square_classes
+
+# Hands by digit multiplicities, with their probability for independent
+# uniform digits, from the fewest repeated digits to the most, so the rare
+# hands are adjacent when small classes are merged
+HANDS = {
+    5: [
+        ("All different", (1, 1, 1, 1, 1), 0.3024),
+        ("One pair", (2, 1, 1, 1), 0.504),
+        ("Two pairs", (2, 2, 1), 0.108),
+        ("Three of a kind", (3, 1, 1), 0.072),
+        ("Full house", (3, 2), 0.009),
+        ("Poker", (4, 1), 0.0045),
+        ("Quintilla", (5,), 0.0001)
+    ],
+    3: [
+        ("All different", (1, 1, 1), 0.72),
+        ("One pair", (2, 1), 0.27),
+        ("Three of a kind", (3,), 0.01)
+    ]
+}
+
+def digits_of(u, digits):
+    """Return the first decimal digits of u in [0, 1) as a string, e.g. 0.0712 -> "071"."""
+    # Round away the float error first, so that 0.0003 * 10^5 = 29.999... gives 30
+    value = min(int(round(u * 10 ** digits, 9)), 10 ** digits - 1)
+    return str(value).zfill(digits)
+
+def hand(u, digits):
+    """Return the multiplicities of the first decimal digits of u, largest first."""
+    return tuple(sorted(Counter(digits_of(u, digits)).values(), reverse=True))
+
+def poker_counts(normalized_sequence, digits=5):
+    """
+    Classify each value by the hand its first decimal digits form.
+
+    Returns:
+        tuple: (labels, observed, expected), one entry per hand of HANDS[digits]
+    """
+    if digits not in HANDS:
+        raise ValueError(f"The poker test uses {' or '.join(str(d) for d in HANDS)} digits")
+    counts = Counter(hand(u, digits) for u in normalized_sequence)
+    n = len(normalized_sequence)
+    hands = HANDS[digits]
+    return (
+        [name for name, _, _ in hands],
+        [counts[pattern] for _, pattern, _ in hands],
+        [n * probability for _, _, probability in hands]
+    )
+
+def poker_test(normalized_sequence, digits=5, min_expected=5):
+    """
+    Perform the poker test on the first 3 or 5 decimal digits of each value.
+
+    Hands that expect fewer than min_expected values are merged with
+    their neighbours (the rare hands are listed last) before adding up
+    the chi-square statistic.
+
+    Args:
+        normalized_sequence (list): List of normalized random numbers
+        digits (int): 3 or 5
+        min_expected (float): Smallest expected count of a group of hands
+
+    Returns:
+        tuple: (chi_square_value, p_value, degrees_of_freedom, table), as chi_square_classes
+    """
+    labels, observed, expected = poker_counts(normalized_sequence, digits)
+    return chi_square_classes(labels, observed, expected, min_expected)
@@ -0,0 +1,131 @@
+from math import isclose
+
+from .combined import WichmannHillGenerator, LEcuyerGenerator, MRG32k3aGenerator
+from .xorshift import Xorshift32Generator, Xorshift64Generator
+from .xoshiro import Xoshiro256StarStarGenerator
+from .splitmix import SplitMix64Generator
+from .pcg import PCG32Generator
+from .mersenne_twister import MersenneTwisterGenerator
+from .chacha import ChaCha20Generator
+from .philox import Philox4x32Generator
+
+# (name, factory, expected outputs, compare floats)
+REFERENCE_VECTORS = [
+    # AS 183 from seeds (1, 2, 3), as CPython 2.7's random.WichmannHill gives them
+    ("Wichmann-Hill outputs", lambda: WichmannHillGenerator(1, 2, 3),
+     [0.03381877363047378, 0.7775418875596665, 0.05273524613909042,
+      0.7446240744053352, 0.49036219114966934], True),
+    # First outputs of MRG32k3a with all seeds 12345 (L'Ecuyer's RngStreams)
+    ("MRG32k3a", MRG32k3aGenerator,
+     [0.12701112204657714, 0.3185275653967945, 0.3091860155832701,
+      0.8258468629271135, 0.22162991578202287], True),
+    # Marsaglia, "Xorshift RNGs" (2003), default seeds of the example code
+    ("Xorshift32", lambda: Xorshift32Generator(2463534242),
+     [723471715, 2497366906, 2064144800], False),
+    ("Xorshift64", lambda: Xorshift64Generator(88172645463325252),
+     [8748534153485358512, 3040900993826735515, 3453997556048239312], False),
+    # State {1, 2, 3, 4}, as in the rand_xoshiro test suite
+    ("Xoshiro256**", lambda: Xoshiro256StarStarGenerator(0, state=(1, 2, 3, 4)),
+     [11520, 0, 1509978240, 1215971899390074240, 1216172134540287360,
+      607988272756665600, 16172922978634559625, 8476171486693032832,
+      10595114339597558777, 2904607092377533576], False),
+    # Reference implementation by Sebastiano Vigna, seed 1234567
+    ("SplitMix64", lambda: SplitMix64Generator(1234567),
+     [6457827717110365317, 3203168211198807973, 9817491932198370423,
+      4593380528125082431, 16408922859458223821], False),
+    # pcg32-demo from the reference C implementation, pcg32_srandom_r(42, 54)
+    ("PCG32", lambda: PCG32Generator(42, 54),
+     [0xA15C02B7, 0x7B47F409, 0xBA1D3330, 0x83D2F293, 0xBFA4784B, 0xCBED606E], False),
+    # First output of init_genrand(5489), the default seed of mt19937ar.c
+    ("MT19937 init_genrand", lambda: MersenneTwisterGenerator(5489), [3499211612], False),
+    # mt19937ar.out: init_by_array({0x123, 0x234, 0x345, 0x456})
+    ("MT19937 init_by_array", lambda: MersenneTwisterGenerator(key=[0x123, 0x234, 0x345, 0x456]),
+     [1067595299, 955945823, 477289528, 4107218783, 4228976476], False),
+    # RFC 7539, 2.3.2: key 00:01:...:1f, nonce 00:00:00:09:00:00:00:4a:00:00:00:00, counter 1
+    ("ChaCha20 block", lambda: ChaCha20Generator(
+        int.from_bytes(bytes(range(32)), "big"), 0x000000090000004A00000000, 1
+    ), [0xE4E7F110, 0x15593BD1, 0x1FDD0F50, 0xC47120A3, 0xC7F4D1C7, 0x0368C033, 0x9AAA2204, 0x4E6CD4C3,
+        0x466482D2, 0x09AA9F07, 0x05D7C214, 0xA2028BD9, 0xD19C12B5, 0xB94E16DE, 0xE883D0CB, 0x4E3C50A2], False),
+    # Random123 kat_vectors, philox4x32 with 10 rounds
+    ("Philox4x32-10 zeros", lambda: Philox4x32Generator(0, 0),
+     [0x6627E8D5, 0xE169C58D, 0xBC57AC4C, 0x9B00DBD8], False),
+    ("Philox4x32-10 ones", lambda: Philox4x32Generator(2 ** 64 - 1, 2 ** 128 - 1),
+     [0x408F276D, 0x41C83B0E, 0xA20BC7C6, 0x6D5451FD], False),
+    ("Philox4x32-10 pi", lambda: Philox4x32Generator(
+        0x299F31D0A4093822, 0x0370734413198A2E85A308D3243F6A88
+    ), [0xD16CFE09, 0x94FDCCEB, 0x5001E420, 0x24126EA1], False)
+]
+
+# (name, factory, index, expected output), for values published far into the sequence
+REFERENCE_VALUES = [
+    # Boost.Random's validation value for ecuyer1988: the 10000th output from seeds (1, 1)
+    ("L'Ecuyer Combined outputs", lambda: LEcuyerGenerator(1, 1), 10000, 2060321752)
+]
+
+# Published periods
+REFERENCE_PERIODS = [
+    # Wichmann and Hill (1982)
+    ("Wichmann-Hill", lambda: WichmannHillGenerator(1, 2, 3), 6953607871644),
+    # L'Ecuyer (1988): (m1 - 1)(m2 - 1) / 2
+    ("L'Ecuyer Combined", lambda: LEcuyerGenerator(12345, 67890), 2305842648436451838)
+]
+
+def compare_outputs(generator, expected, floats=False, rel_tol=1e-12):
+    """
+    Reset the generator and compare its first outputs with reference values.
+
+    Args:
+        generator (Generator): Generator built with the reference parameters
+        expected (list): Published outputs, in order
+        floats (bool): Compare next_float() instead of next_int()
+        rel_tol (float): Relative tolerance for floats
+
+    Returns:
+        list: (index, expected, actual) for each mismatch, empty if all match
+    """
+    generator.reset()
+    mismatches = []
+    for index, value in enumerate(expected):
+        if floats:
+            actual = generator.next_float()
+            matches = isclose(actual, value, rel_tol=rel_tol)
+        else:
+            actual = generator.next_int()
+            matches = actual == value
+        if not matches:
+            mismatches.append((index, value, actual))
+    return mismatches
+
+def verify(reference_vectors):
+    """
+    Check a list of reference vectors.
+
+    Args:
+        reference_vectors (list): (name, factory, expected, floats) tuples,
+                                  where factory() builds the generator
+
+    Returns:
+        dict: name -> list of mismatches (empty when the vector matches)
+    """
+    return {
+        name: compare_outputs(factory(), expected, floats)
+        for name, factory, expected, floats in reference_vectors
+    }
+
+def verify_references():
+    """
+    Check every generator against its published outputs, values and periods.
+
+    Returns:
+        dict: name -> list of mismatches, empty when the reference matches
+    """
+    results = verify(REFERENCE_VECTORS)
+    for name, factory, index, expected in REFERENCE_VALUES:
+        generator = factory()
+        generator.jump(index - 1)
+        actual = generator.next_int()
+        results[name] = [] if actual == expected else [(index - 1, expected, actual)]
+    for name, factory, expected in REFERENCE_PERIODS:
+        lam = factory().period()[1]
+        results[name] = [] if lam == expected else [("period", expected, lam)]
+    return results
@@ -0,0 +1,137 @@
+from math import factorial, sqrt
+from scipy.stats import norm
+
+from .chi_square import chi_square_classes
+
+def runs_up_down(normalized_sequence):
+    """
+    Return the lengths of the runs up and down.
+
+    Each pair of consecutive values gives "+" when the second is greater
+    and "-" otherwise; a run is a maximal block of equal signs, so N values
+    give runs whose lengths add up to N - 1.
+    """
+    signs = [b > a for a, b in zip(normalized_sequence, normalized_sequence[1:])]
+    return _run_lengths(signs)
+
+def runs_above_below(normalized_sequence, mean=0.5):
+    """
+    Return (run lengths, n1, n2) for the runs above and below the mean.
+
+    Values greater than the mean are above it, the others below; n1 and
+    n2 count the values above and below.
+    """
+    above = [u > mean for u in normalized_sequence]
+    return _run_lengths(above), sum(above), len(above) - sum(above)
+
+def _run_lengths(flags):
+    lengths = []
+    for index, flag in enumerate(flags):
+        if index and flag == flags[index - 1]:
+            lengths[-1] += 1
+        else:
+            lengths.append(1)
+    return lengths
+
+def _z_test(runs, mean, variance):
+    if variance <= 0:
+        raise ValueError("Too few values, the number of runs has no variance")
+    z = (runs - mean) / sqrt(variance)
+    return z, 2 * norm.sf(abs(z))
+
+def runs_up_down_test(normalized_sequence):
+    """
+    Test the number of runs up and down.
+
+    For N independent values the number of runs a has mean (2N - 1) / 3
+    and variance (16N - 29) / 90, and is close to normal for N > 20.
+
+    Args:
+        normalized_sequence (list): List of normalized random numbers
+
+    Returns:
+        tuple: (runs, expected_runs, variance, z, p_value), two-sided p-value
+    """
+    n = len(normalized_sequence)
+    if n < 3:
+        raise ValueError("The runs up and down test needs at least 3 values")
+    runs = len(runs_up_down(normalized_sequence))
+    mean = (2 * n - 1) / 3
+    variance = (16 * n - 29) / 90
+    return (runs, mean, variance, *_z_test(runs, mean, variance))
+
+def runs_above_below_test(normalized_sequence, mean=0.5):
+    """
+    Test the number of runs above and below the mean.
+
+    With n1 values above and n2 below (N = n1 + n2), the number of runs b
+    has mean 2 n1 n2 / N + 1/2 and variance
+    2 n1 n2 (2 n1 n2 - N) / (N^2 (N - 1)).
+
+    Args:
+        normalized_sequence (list): List of normalized random numbers
+        mean (float): Value that splits the sequence (0.5 for U(0, 1))
+
+    Returns:
+        tuple: (runs, expected_runs, variance, z, p_value), two-sided p-value
+    """
+    lengths, n1, n2 = runs_above_below(normalized_sequence, mean)
+    n = n1 + n2
+    if n1 == 0 or n2 == 0:
+        raise ValueError("All the values are on the same side of the mean")
+    expected = 2 * n1 * n2 / n + 0.5
+    variance = 2 * n1 * n2 * (2 * n1 * n2 - n) / (n ** 2 * (n - 1))
+    return (len(lengths), expected, variance, *_z_test(len(lengths), expected, variance))
+
+def _length_classes(lengths, expected_of, total_expected):
+    """Observed and expected runs of length 1 .. L - 1 and L or more, L the longest run."""
+    longest = max(lengths)
+    labels, observed, expected = [], [], []
+    for i in range(1, longest):
+        labels.append(str(i))
+        observed.append(lengths.count(i))
+        expected.append(expected_of(i))
+    labels.append(f">= {longest}")
+    observed.append(sum(1 for length in lengths if length >= longest))
+    expected.append(max(0.0, total_expected - sum(expected)))
+    return labels, observed, expected
+
+def runs_up_down_length_test(normalized_sequence, min_expected=5):
+    """
+    Chi-square test on the lengths of the runs up and down.
+
+    The expected number of runs of length i among N values is
+    2 / (i + 3)! * (N (i^2 + 3i + 1) - (i^3 + 3i^2 - i - 4)) for i <= N - 2.
+
+    Returns:
+        tuple: (chi_square_value, p_value, degrees_of_freedom, table), as chi_square_classes
+    """
+    n = len(normalized_sequence)
+    if n < 3:
+        raise ValueError("The runs length test needs at least 3 values")
+    expected_of = lambda i: 2 * (n * (i * i + 3 * i + 1) - (i ** 3 + 3 * i * i - i - 4)) / factorial(i + 3)
+    labels, observed, expected = _length_classes(
+        runs_up_down(normalized_sequence), expected_of, (2 * n - 1) / 3
+    )
+    return chi_square_classes(labels, observed, expected, min_expected)
+
+def runs_above_below_length_test(normalized_sequence, mean=0.5, min_expected=5):
+    """
+    Chi-square test on the lengths of the runs above and below the mean.
+
+    Runs of length i are expected N w_i / E(I) times, with
+    w_i = (n1/N)^i (n2/N) + (n1/N) (n2/N)^i and E(I) = n1/n2 + n2/n1
+    the mean run length.
+
+    Returns:
+        tuple: (chi_square_value, p_value, degrees_of_freedom, table), as chi_square_classes
+    """
+    lengths, n1, n2 = runs_above_below(normalized_sequence, mean)
+    n = n1 + n2
+    if n1 == 0 or n2 == 0:
+        raise ValueError("All the values are on the same side of the mean")
+    p, q = n1 / n, n2 / n
+    mean_length = n1 / n2 + n2 / n1
+    expected_of = lambda i: n * (p ** i * q + p * q ** i) / mean_length
+    labels, observed, expected = _length_classes(lengths, expected_of, n / mean_length)
+    return chi_square_classes(labels, observed, expected, min_expected)
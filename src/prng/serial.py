@@ -0,0 +1,68 @@
+from collections import Counter
+from scipy.stats import chi2
+
+def tuples(normalized_sequence, d, overlapping=False):
+    """
+    Return the d-tuples of consecutive values.
+
+    Non-overlapping tuples take the values d at a time; overlapping ones
+    start at every value and wrap around the end, so n values give n tuples.
+    """
+    n = len(normalized_sequence)
+    if overlapping:
+        return [tuple(normalized_sequence[(i + j) % n] for j in range(d)) for i in range(n)]
+    return [tuple(normalized_sequence[i:i + d]) for i in range(0, n - d + 1, d)]
+
+def default_cells(count, d, min_expected=5, largest=10):
+    """Return the largest k <= largest with at least min_expected tuples per cell, at least 2."""
+    k = int((count / min_expected) ** (1 / d))
+    return max(2, min(largest, k))
+
+def _psi_square(normalized_sequence, d, k, overlapping):
+    """Return (psi^2, number of tuples) over the k^d cells."""
+    found = tuples(normalized_sequence, d, overlapping)
+    counts = Counter(tuple(min(int(u * k), k - 1) for u in t) for t in found)
+    expected = len(found) / k ** d
+    # Empty cells add expected^2 / expected = expected each
+    empty = k ** d - len(counts)
+    psi_square = sum((obs - expected) ** 2 / expected for obs in counts.values()) + empty * expected
+    return psi_square, len(found)
+
+def serial_test(normalized_sequence, d=2, k=None, overlapping=False):
+    """
+    Perform the serial test: uniformity of d-tuples over the k^d cells of [0, 1)^d.
+
+    Non-overlapping tuples are independent, so the usual chi-square with
+    k^d - 1 degrees of freedom applies. Overlapping tuples are not, and
+    use Good's statistic psi^2_d - psi^2_(d-1) instead, which is
+    chi-square with k^d - k^(d-1) degrees of freedom.
+
+    Args:
+        normalized_sequence (list): List of normalized random numbers
+        d (int): Dimension of the tuples (2 or 3)
+        k (int): Intervals per axis, by default the most that still
+                 expects 5 tuples per cell (at most 10)
+        overlapping (bool): Use overlapping tuples
+
+    Returns:
+        tuple: (chi_square_value, p_value, degrees_of_freedom, k)
+    """
+    if d not in (2, 3):
+        raise ValueError("The serial test is available for d = 2 and d = 3")
+    n = len(normalized_sequence)
+    count = n if overlapping else n // d
+    if count < 2:
+        raise ValueError(f"Not enough values to form {d}-tuples")
+    if k is None:
+        k = default_cells(count, d)
+    if k < 2:
+        raise ValueError("k must be at least 2")
+
+    chi_square, _ = _psi_square(normalized_sequence, d, k, overlapping)
+    if overlapping:
+        chi_square -= _psi_square(normalized_sequence, d - 1, k, overlapping)[0]
+        degrees_of_freedom = k ** d - k ** (d - 1)
+    else:
+        degrees_of_freedom = k ** d - 1
+    p_value = 1 - chi2.cdf(chi_square, degrees_of_freedom)
+    return chi_square, p_value, degrees_of_freedom, k
@@ -0,0 +1,50 @@
+from .generator import Generator
+from .bits import MASK64, unit_from_64
+
+GOLDEN_GAMMA = 0x9E3779B97F4A7C15
+
+def mix64(z):
+    """SplitMix64 output function (a variant of the MurmurHash3 finalizer)."""
+    z = ((z ^ (z >> 30)) * 0xBF58476D1CE4E5B9) & MASK64
+    z = ((z ^ (z >> 27)) * 0x94D049BB133111EB) & MASK64
+    return z ^ (z >> 31)
+
+class SplitMix64Generator(Generator):
+    """SplitMix64: the Weyl sequence x += 0x9E3779B97F4A7C15 (mod 2^64) hashed by mix64."""
+
+    NAME = "SplitMix64"
+    PARAMS = {
+        "x0": {"description": "Seed (64 bits)", "default": 1234567, "minimum": 0, "maximum": MASK64}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0):
+        self.x0 = x0
+        super().__init__()
+
+    def validate(self):
+        if not 0 <= self.x0 <= MASK64:
+            raise ValueError("x0 must fit in 64 bits")
+
+    @property
+    def modulus(self):
+        return 2 ** 64
+
+    def initial_state(self):
+        return self.x0
+
+    def transition(self, state):
+        return (state + GOLDEN_GAMMA) & MASK64
+
+    def output(self, state):
+        return mix64(state)
+
+    def normalize(self, value):
+        return unit_from_64(value)
+
+    def jump(self, steps):
+        self._state = (self._state + steps * GOLDEN_GAMMA) & MASK64
+
+    def period(self, max_steps=10**6):
+        # The increment is odd, so the Weyl sequence visits every 64-bit value
+        return 0, 2 ** 64
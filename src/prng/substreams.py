@@ -0,0 +1,56 @@
+import copy
+
+def substreams(generator, k, distance):
+    """
+    Split one parameter set into k non-overlapping streams.
+
+    Stream i starts i * distance values after the seed, reached with the
+    generator's jump(), which is O(log n) for the congruential methods.
+    Each stream may draw up to distance values without running into the
+    next one.
+
+    Args:
+        generator (Generator): Generator whose seed parameters are shared
+        k (int): Number of streams
+        distance (int): Offset between the starts of consecutive streams
+
+    Returns:
+        list: k independent Generator objects
+
+    Raises:
+        ValueError: If the streams would overlap within the period
+    """
+    if k < 1 or distance < 1:
+        raise ValueError("k and distance must be positive numbers")
+    check_no_overlap(generator, k, distance)
+
+    streams = []
+    for i in range(k):
+        stream = copy.copy(generator)
+        stream.reset()
+        stream.jump(i * distance)
+        streams.append(stream)
+    return streams
+
+def check_no_overlap(generator, k, distance):
+    """
+    Raise ValueError unless k streams of length distance fit within the period.
+
+    The streams use the states 1 to k * distance after the seed. The
+    states 0 to mu + lam - 1 are all different and state mu + lam is
+    state mu again, which is only the unused seed state when mu = 0.
+    """
+    needed = k * distance
+    max_steps = max(needed, 10**6)
+    period = generator.period(max_steps)
+    if period is None:
+        # The cycle is longer than max_steps >= needed
+        return
+    mu, lam = period
+    # Index of the last state before one is repeated
+    last = mu + lam - 1 if mu > 0 else lam
+    if needed > last:
+        raise ValueError(
+            f"{k} streams of {distance} values need {needed} distinct states, "
+            f"but the sequence repeats after {last}"
+        )
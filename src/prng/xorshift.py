@@ -0,0 +1,77 @@
+from .generator import Generator
+from .bits import MASK32, MASK64, unit_from_32_pair, unit_from_64
+
+class Xorshift32Generator(Generator):
+    """Marsaglia's xorshift32: x ^= x << 13; x ^= x >> 17; x ^= x << 5."""
+
+    NAME = "Xorshift32"
+    PARAMS = {
+        "x0": {"description": "Seed (32 bits, not 0)", "default": 2463534242, "maximum": MASK32}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0):
+        self.x0 = x0
+        super().__init__()
+
+    def validate(self):
+        if not 0 < self.x0 <= MASK32:
+            raise ValueError("x0 must be a non-zero 32-bit number")
+
+    @property
+    def modulus(self):
+        return 2 ** 32
+
+    def initial_state(self):
+        return self.x0
+
+    def transition(self, state):
+        state ^= (state << 13) & MASK32
+        state ^= state >> 17
+        state ^= (state << 5) & MASK32
+        return state
+
+    def next_double(self):
+        """Return the next two outputs as a float with 53-bit precision."""
+        # next_float() stays normalize(next_int()), a single 32-bit output
+        return unit_from_32_pair(self.next_int(), self.next_int())
+
+    def period(self, max_steps=10**6):
+        # Every non-zero state lies on a single cycle
+        return 0, 2 ** 32 - 1
+
+class Xorshift64Generator(Generator):
+    """Marsaglia's xorshift64: x ^= x << 13; x ^= x >> 7; x ^= x << 17."""
+
+    NAME = "Xorshift64"
+    PARAMS = {
+        "x0": {"description": "Seed (64 bits, not 0)", "default": 88172645463325252, "maximum": MASK64}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0):
+        self.x0 = x0
+        super().__init__()
+
+    def validate(self):
+        if not 0 < self.x0 <= MASK64:
+            raise ValueError("x0 must be a non-zero 64-bit number")
+
+    @property
+    def modulus(self):
+        return 2 ** 64
+
+    def initial_state(self):
+        return self.x0
+
+    def transition(self, state):
+        state ^= (state << 13) & MASK64
+        state ^= state >> 7
+        state ^= (state << 17) & MASK64
+        return state
+
+    def normalize(self, value):
+        return unit_from_64(value)
+
+    def period(self, max_steps=10**6):
+        return 0, 2 ** 64 - 1
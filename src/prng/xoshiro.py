@@ -0,0 +1,66 @@
+from .generator import Generator
+from .bits import MASK64, rotl64, unit_from_64
+from .splitmix import SplitMix64Generator
+
+class Xoshiro256StarStarGenerator(Generator):
+    """
+    Blackman and Vigna's xoshiro256**: 256 bits of state, output rotl(s1 * 5, 7) * 9.
+
+    The state is filled from x0 with SplitMix64, as the authors recommend,
+    unless an explicit four-word state is given.
+    """
+
+    NAME = "Xoshiro256**"
+    PARAMS = {
+        "x0": {"description": "Seed (64 bits)", "default": 1234567, "minimum": 0, "maximum": MASK64}
+    }
+    SEED_PARAMS = ("x0",)
+
+    def __init__(self, x0, state=None):
+        self.x0 = x0
+        self.words = tuple(state) if state is not None else None
+        super().__init__()
+
+    def validate(self):
+        if not 0 <= self.x0 <= MASK64:
+            raise ValueError("x0 must fit in 64 bits")
+        if self.words is not None:
+            if len(self.words) != 4 or not all(0 <= word <= MASK64 for word in self.words):
+                raise ValueError("The state must be four 64-bit words")
+            if not any(self.words):
+                raise ValueError("The state must not be all zero")
+
+    @property
+    def modulus(self):
+        return 2 ** 64
+
+    def initial_state(self):
+        if self.words is not None:
+            return self.words
+        return tuple(SplitMix64Generator(self.x0).generate(4))
+
+    def transition(self, state):
+        s0, s1, s2, s3 = state
+        t = (s1 << 17) & MASK64
+        s2 ^= s0
+        s3 ^= s1
+        s1 ^= s2
+        s0 ^= s3
+        s2 ^= t
+        s3 = rotl64(s3, 45)
+        return (s0, s1, s2, s3)
+
+    def output(self, state):
+        return (rotl64((state[1] * 5) & MASK64, 7) * 9) & MASK64
+
+    def next_int(self):
+        # The output is taken from the state before the update
+        value = self.output(self._state)
+        self._state = self.transition(self._state)
+        return value
+
+    def normalize(self, value):
+        return unit_from_64(value)
+
+    def period(self, max_steps=10**6):
+        return 0, 2 ** 256 - 1
@@ -0,0 +1,16 @@
+import unittest
+
+from prng.cavp import verify_fixtures
+
+class CAVPTest(unittest.TestCase):
+    def test_fixtures(self):
+        results = verify_fixtures()
+        # no_reseed, pr_false and pr_true, for Hash_DRBG and HMAC_DRBG
+        self.assertEqual(len(results), 6)
+        for name, (run, mismatches) in results.items():
+            with self.subTest(name):
+                self.assertGreater(run, 0)
+                self.assertEqual(mismatches, [])
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,13 @@
+import unittest
+
+from prng.chi_square import chi_square_test, chi_square_test_stream
+
+class ChiSquareTest(unittest.TestCase):
+    def test_empty_sequence(self):
+        with self.assertRaises(ValueError):
+            chi_square_test([])
+        with self.assertRaises(ValueError):
+            chi_square_test_stream(iter(()))
+
+if __name__ == "__main__":
+    unittest.main()
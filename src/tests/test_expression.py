@@ -0,0 +1,13 @@
+import unittest
+
+from prng.expression import advise
+
+class ExpressionTest(unittest.TestCase):
+    def test_deep_expressions_raise_value_error(self):
+        for expression in ("+".join(["1"] * 3000), "-" * 3000 + "1", "(" * 100000 + "1" + ")" * 100000):
+            with self.subTest(expression[:10]):
+                with self.assertRaises(ValueError):
+                    advise(expression, [])
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,16 @@
+import unittest
+
+from prng.mid_square import extract_middle_digits
+
+class MiddleDigitsTest(unittest.TestCase):
+    def test_short_squares_are_padded_to_2d_digits(self):
+        # 3^2 = 00000009 and 0100^2 = 00010000
+        self.assertEqual(extract_middle_digits(3 ** 2, 4), 0)
+        self.assertEqual(extract_middle_digits(100 ** 2, 4), 100)
+
+    def test_full_square(self):
+        # 5735^2 = 32890225
+        self.assertEqual(extract_middle_digits(5735 ** 2, 4), 8902)
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,19 @@
+import unittest
+
+from prng.poker import digits_of, hand
+
+class PokerTest(unittest.TestCase):
+    def test_exact_decimals_keep_their_digits(self):
+        # 0.0003 * 10^5 and 0.29 * 100 are just below 30 and 29 in floating point
+        self.assertEqual(digits_of(0.0003, 5), "00030")
+        self.assertEqual(hand(0.0003, 5), (4, 1))
+        self.assertEqual(digits_of(0.29, 3), "290")
+        self.assertEqual(digits_of(0.29, 5), "29000")
+
+    def test_every_mid_square_output(self):
+        # The normalized 4-digit values x / 10000, read with 5 digits
+        for x in range(10000):
+            self.assertEqual(digits_of(x / 10000, 5), str(x).zfill(4) + "0")
+
+if __name__ == "__main__":
+    unittest.main()
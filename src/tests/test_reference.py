@@ -0,0 +1,14 @@
+import unittest
+
+from prng.reference import REFERENCE_VECTORS, REFERENCE_VALUES, REFERENCE_PERIODS, verify_references
+
+class ReferenceTest(unittest.TestCase):
+    def test_published_outputs_and_periods(self):
+        results = verify_references()
+        self.assertEqual(len(results), len(REFERENCE_VECTORS) + len(REFERENCE_VALUES) + len(REFERENCE_PERIODS))
+        for name, mismatches in results.items():
+            with self.subTest(name):
+                self.assertEqual(mismatches, [])
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,22 @@
+import unittest
+
+from prng.runs import runs_above_below_test, runs_up_down_test
+
+class RunsTest(unittest.TestCase):
+    def test_one_value_on_each_side_of_the_mean(self):
+        # n1 = n2 = 1 gives a variance of 0
+        with self.assertRaises(ValueError):
+            runs_above_below_test([0.2, 0.7])
+
+    def test_too_few_values_for_runs_up_and_down(self):
+        with self.assertRaises(ValueError):
+            runs_up_down_test([0.2, 0.7])
+
+    def test_alternating_values(self):
+        runs, expected, variance, z, p_value = runs_above_below_test([0.2, 0.7] * 10)
+        self.assertEqual(runs, 20)
+        self.assertAlmostEqual(expected, 10.5)
+        self.assertGreater(z, 0)
+
+if __name__ == "__main__":
+    unittest.main()
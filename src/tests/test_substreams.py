@@ -0,0 +1,22 @@
+import unittest
+
+from prng.congruential_mixed import MixedCongruentialGenerator
+from prng.substreams import substreams
+
+class SubstreamsTest(unittest.TestCase):
+    def test_full_cycle_without_tail(self):
+        # Full period 16, so two streams of 8 share no value
+        streams = substreams(MixedCongruentialGenerator(1, 5, 3, 16), 2, 8)
+        values = [value for stream in streams for value in stream.generate(8)]
+        self.assertEqual(sorted(values), list(range(16)))
+
+    def test_tail_leaves_one_state_less(self):
+        # 2 -> 5 -> 1 -> 3 -> 7 -> 5: mu = 1, lam = 4, so only 4 distinct outputs
+        generator = MixedCongruentialGenerator(2, 2, 1, 10)
+        self.assertEqual(generator.period(), (1, 4))
+        self.assertEqual(substreams(generator, 1, 4)[0].generate(4), [5, 1, 3, 7])
+        with self.assertRaises(ValueError):
+            substreams(generator, 1, 5)
+
+if __name__ == "__main__":
+    unittest.main()